@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/yourusername/ai-agent-team/internal/agents"
 	"github.com/yourusername/ai-agent-team/internal/llmfactory"
 	"github.com/yourusername/ai-agent-team/internal/models"
 	"github.com/yourusername/ai-agent-team/internal/tui"
@@ -42,6 +43,31 @@ func main() {
 	// Choose team configuration
 	config := selectTeamConfig()
 
+	// Add any custom roles defined in the roles directory
+	customRoles, err := agents.LoadRoleDefinitions(agents.RolesDir())
+	if err != nil {
+		log.Fatalf("Error loading custom roles: %v", err)
+	}
+	config.CustomRoles = append(config.CustomRoles, customRoles...)
+
+	// Spending caps from DISCUSSION_BUDGET_* and AGENT_BUDGET_* env vars
+	config.Budget = models.BudgetFromEnv("DISCUSSION_BUDGET")
+	config.AgentBudget = models.BudgetFromEnv("AGENT_BUDGET")
+
+	// DISCUSSION_STRICT=true stops the run when an agent contribution fails
+	config.StrictFailures, _ = strconv.ParseBool(os.Getenv("DISCUSSION_STRICT"))
+
+	// DISCUSSION_CRITERIA=Feasibility=2,Impact,Cost replaces the scoring criteria
+	criteria, err := models.ParseCriteria(os.Getenv("DISCUSSION_CRITERIA"))
+	if err != nil {
+		log.Fatalf("Error in DISCUSSION_CRITERIA: %v", err)
+	}
+	config.Criteria = criteria
+
+	// Models are assigned by MODEL_POLICY_* rules unless MODEL_ASSIGNMENT=llm
+	config.ModelAssignment = os.Getenv("MODEL_ASSIGNMENT")
+	config.ModelPolicy = models.ModelPolicyFromEnv("MODEL_POLICY")
+
 	// Get topic from user
 	fmt.Println("\n📝 What topic would you like the AI team to explore?")
 	fmt.Print("> ")
@@ -57,6 +83,9 @@ func main() {
 		log.Fatal("Topic cannot be empty")
 	}
 
+	// Recall related past discussions and remember this one
+	config.TeamMemory = askYesNo(reader, "Use team memory from past discussions? (y/N)", false)
+
 	fmt.Println("\n🚀 Launching AI Agent Team...\n")
 	time.Sleep(500 * time.Millisecond) // Brief pause for effect
 
@@ -90,6 +119,21 @@ func main() {
 	// Print final summary
 	if discussion != nil && discussion.FinalIdea != nil {
 		fmt.Println("\n" + strings.Repeat("═", 60))
+		if discussion.StopReason != "" {
+			fmt.Printf("\n🔄 Rounds: %d (stopped: %s)\n", discussion.Round, discussion.StopReason)
+		}
+		if b := discussion.Budget; b != nil {
+			fmt.Printf("💸 Estimated spend: %d tokens, $%.2f (budget: %s, %d steps skipped)\n", b.Used.Tokens, b.Used.Cost, b.Level, len(b.Degradations))
+		}
+		if n := len(discussion.Failures); n > 0 {
+			fmt.Printf("⚠️  Failed contributions: %d (%s)\n", n, discussion.FailureSummary())
+		}
+		if roles := discussion.Metrics.ByRole(); len(roles) > 0 {
+			fmt.Printf("⏱️ Slowest agent: %s (%.1fs over %d calls)\n", roles[0].Role, roles[0].Duration.Seconds(), roles[0].Calls)
+		}
+		if discussion.Summary != "" {
+			fmt.Printf("\n📝 %s\n", discussion.Summary)
+		}
 		fmt.Printf("\n⭐ FINAL SELECTED IDEA:\n\n")
 		fmt.Printf("   %s\n", discussion.FinalIdea.Title)
 		fmt.Printf("   Score: %.1f/10\n\n", discussion.FinalIdea.Score)
@@ -181,6 +225,19 @@ func customTeamConfig(reader *bufio.Reader) *models.TeamConfig {
 	}
 
 	config.DeepDive = askYesNo(reader, "Enable deep dive mode? (Y/n)", true)
+	config.AdaptiveRounds = askYesNo(reader, "Adapt round count to convergence (stop early or extend)? (y/N)", false)
+	config.Tournament = askYesNo(reader, "Rank top ideas in a head-to-head tournament? (y/N)", false)
+	if askYesNo(reader, "Score ideas with a panel of judges? (y/N)", false) {
+		config.Judges = models.DefaultJudges()
+		fmt.Print("Aggregate judge scores by mean, median or trimmed_mean [median]: ")
+		method, _ := reader.ReadString('\n')
+		method = strings.TrimSpace(method)
+		if !models.IsAggregation(method) {
+			method = models.AggregateMedian
+		}
+		config.ScoreAggregation = method
+	}
+	config.DynamicTurns = askYesNo(reader, "Let the team leader choose who speaks next each turn? (y/N)", false)
 
 	return config
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -10,9 +11,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/yourusername/ai-agent-team/internal/agents"
+	"github.com/yourusername/ai-agent-team/internal/llm"
 	"github.com/yourusername/ai-agent-team/internal/llmfactory"
 	"github.com/yourusername/ai-agent-team/internal/models"
 	"github.com/yourusername/ai-agent-team/internal/orchestrator"
+	"github.com/yourusername/ai-agent-team/internal/report"
 	"github.com/yourusername/ai-agent-team/internal/tools"
 )
 
@@ -46,8 +50,40 @@ func main() {
 		}
 	}
 
+	// Handle --fork <file> [--at <round>]: branch a saved discussion and
+	// continue it with a different team
+	for i, arg := range os.Args[1:] {
+		if arg == "--fork" {
+			if i+2 >= len(os.Args) {
+				log.Fatal("Usage: --fork <discussion.json> [--at <round>]")
+			}
+			round := -1
+			if i+4 < len(os.Args) && os.Args[i+3] == "--at" {
+				round, err = strconv.Atoi(os.Args[i+4])
+				if err != nil {
+					log.Fatalf("Invalid round %q: %v", os.Args[i+4], err)
+				}
+			}
+			runFork(cfg, os.Args[i+2], round)
+			return
+		}
+	}
+
+	// Handle --experiment <arm>,<arm>[,...]: run one topic under several
+	// preset teams or models at once and compare the results
+	for i, arg := range os.Args[1:] {
+		if arg == "--experiment" {
+			if i+2 >= len(os.Args) {
+				log.Fatal("Usage: --experiment <team>[@model],<team>[@model][,...] (teams: standard, extended, full)")
+			}
+			runExperiment(cfg, os.Args[i+2])
+			return
+		}
+	}
+
 	// Choose team configuration
 	config := selectTeamConfig()
+	prepareConfig(config)
 
 	// Get topic from user
 	fmt.Println("\n📝 What topic would you like the AI team to explore?")
@@ -64,6 +100,9 @@ func main() {
 		log.Fatal("Topic cannot be empty")
 	}
 
+	// Recall related past discussions and remember this one
+	config.TeamMemory = askYesNo(reader, "Use team memory from past discussions? (y/N)", false)
+
 	fmt.Println("\n🚀 Starting AI agent team discussion...\n")
 	printTeamComposition(config)
 	fmt.Println()
@@ -103,12 +142,71 @@ func main() {
 	// Print summary
 	discussion := orch.GetDiscussion()
 	if discussion != nil {
+		saveDiscussion(discussion)
+		exportPlan(discussion)
 		fmt.Println("\n📊 Discussion Summary:")
 		fmt.Printf("   Topic: %s\n", discussion.Topic)
 		fmt.Printf("   Team Size: %d agents\n", config.TeamSize())
 		fmt.Printf("   Rounds Completed: %d\n", discussion.Round)
+		if discussion.StopReason != "" {
+			fmt.Printf("   Stop Reason: %s\n", discussion.StopReason)
+		}
+		if b := discussion.Budget; b != nil {
+			fmt.Printf("   Estimated Spend: %d tokens, $%.2f over %d calls (budget: %s)\n", b.Used.Tokens, b.Used.Cost, b.Used.Calls, b.Level)
+			for _, d := range b.Degradations {
+				fmt.Printf("     - %s\n", d)
+			}
+		}
 		fmt.Printf("   Ideas Generated: %d\n", len(discussion.Ideas))
+		if n := len(discussion.UnscoredIdeas); n > 0 {
+			fmt.Printf("   Unscored Ideas: %d (not covered by the evaluation)\n", n)
+		}
 		fmt.Printf("   Messages Exchanged: %d\n", len(discussion.Messages))
+		if open := discussion.UnansweredQuestions(); len(open) > 0 {
+			fmt.Printf("   Unanswered Questions: %d\n", len(open))
+		}
+		if len(discussion.Failures) > 0 {
+			fmt.Printf("   Failed Contributions: %d (%s)\n", len(discussion.Failures), discussion.FailureSummary())
+			for _, f := range discussion.Failures {
+				fmt.Printf("     - %s in %s: %s\n", f.Agent, f.Phase, f.Cause)
+			}
+		}
+
+		if len(discussion.RoundSummaries) > 0 {
+			fmt.Println("\n🧭 Rounds:")
+			for _, rs := range discussion.RoundSummaries {
+				fmt.Printf("   Round %d: %d idea(s) added, %d set aside\n", rs.Round, len(rs.IdeasAdded), len(rs.IdeasDropped))
+				for _, insight := range rs.KeyInsights {
+					fmt.Printf("      • %s\n", insight)
+				}
+				if rs.Focus != "" {
+					fmt.Printf("      → Next focus: %s\n", rs.Focus)
+				}
+			}
+		}
+
+		if m := discussion.Metrics; m != nil && len(m.Phases) > 0 {
+			fmt.Println("\n⏱️ Time by Phase:")
+			for _, p := range m.Phases {
+				label := p.Label
+				if p.Round > 0 {
+					label = fmt.Sprintf("%s (round %d)", p.Label, p.Round)
+				}
+				fmt.Printf("   %-32s %6.1fs  %2d call(s)", label, p.Duration.Seconds(), p.Calls)
+				if p.Errors > 0 {
+					fmt.Printf(", %d error(s)", p.Errors)
+				}
+				fmt.Printf(" [%s]\n", p.Outcome)
+			}
+			fmt.Println("   Slowest agents:")
+			for _, r := range m.ByRole() {
+				fmt.Printf("   %-32s %6.1fs  %2d call(s), %d tokens, %d retries (%s)\n", r.Role, r.Duration.Seconds(), r.Calls, r.Tokens, r.Retries, r.Model)
+			}
+		}
+
+		if discussion.Summary != "" {
+			fmt.Printf("\n📝 Executive Summary:\n   %s\n", discussion.Summary)
+		}
 
 		if discussion.FinalIdea != nil {
 			fmt.Printf("\n⭐ Final Selected Idea:\n")
@@ -131,11 +229,302 @@ func main() {
 			}
 		}
 
+		// Follow up on the final idea, as many levels deep as the user likes
+		drillDowns(reader, cfg, config, discussion)
+
 		fmt.Println("\n" + strings.Repeat("═", 60))
 		fmt.Println("🎉 Thank you for using AI Agent Team!")
 	}
 }
 
+// prepareConfig adds custom roles from the roles directory and spending
+// caps from DISCUSSION_BUDGET_* and AGENT_BUDGET_* env vars.
+func prepareConfig(config *models.TeamConfig) {
+	customRoles, err := agents.LoadRoleDefinitions(agents.RolesDir())
+	if err != nil {
+		log.Fatalf("Error loading custom roles: %v", err)
+	}
+	config.CustomRoles = append(config.CustomRoles, customRoles...)
+
+	config.Budget = models.BudgetFromEnv("DISCUSSION_BUDGET")
+	config.AgentBudget = models.BudgetFromEnv("AGENT_BUDGET")
+
+	// DISCUSSION_STRICT=true stops the run when an agent contribution fails
+	config.StrictFailures, _ = strconv.ParseBool(os.Getenv("DISCUSSION_STRICT"))
+
+	// DISCUSSION_CRITERIA=Feasibility=2,Impact,Cost replaces the scoring criteria
+	criteria, err := models.ParseCriteria(os.Getenv("DISCUSSION_CRITERIA"))
+	if err != nil {
+		log.Fatalf("Error in DISCUSSION_CRITERIA: %v", err)
+	}
+	config.Criteria = criteria
+
+	// Models are assigned by MODEL_POLICY_* rules unless MODEL_ASSIGNMENT=llm
+	config.ModelAssignment = os.Getenv("MODEL_ASSIGNMENT")
+	config.ModelPolicy = models.ModelPolicyFromEnv("MODEL_POLICY")
+}
+
+// saveDiscussion writes the discussion to discussion_<id>.json so it can be
+// forked later, and returns the file name.
+func saveDiscussion(d *models.Discussion) string {
+	path := fmt.Sprintf("discussion_%s.json", d.ID)
+	if err := models.SaveDiscussion(path, d); err != nil {
+		log.Printf("Warning: Could not save discussion: %v", err)
+		return ""
+	}
+	fmt.Printf("💾 Discussion saved to: %s (fork it with --fork %s --at <round>)\n", path, path)
+	return path
+}
+
+// exportPlan writes the final idea's implementation plan as a Mermaid gantt
+// chart starting today and an issue-tracker CSV next to the saved discussion.
+func exportPlan(d *models.Discussion) {
+	if d.FinalIdea == nil || d.FinalIdea.Plan == nil {
+		return
+	}
+	plan := d.FinalIdea.Plan
+
+	ganttFile := fmt.Sprintf("plan_%s.mmd", d.ID)
+	if err := os.WriteFile(ganttFile, []byte(report.PlanToMermaidGantt(d.FinalIdea.Title, plan, time.Now())), 0644); err != nil {
+		log.Printf("Warning: Could not save plan timeline: %v", err)
+	} else {
+		fmt.Printf("🗓️  Plan timeline saved to: %s (Mermaid gantt)\n", ganttFile)
+	}
+
+	issues, err := report.PlanToIssuesCSV(plan)
+	if err == nil {
+		issuesFile := fmt.Sprintf("issues_%s.csv", d.ID)
+		err = os.WriteFile(issuesFile, issues, 0644)
+		if err == nil {
+			fmt.Printf("📋 Plan tasks saved to: %s (%d issues)\n", issuesFile, len(plan.Tasks()))
+		}
+	}
+	if err != nil {
+		log.Printf("Warning: Could not save plan issues: %v", err)
+	}
+}
+
+// runFork branches the saved discussion at path after round (asking when
+// round is negative), continues it with a newly chosen team and optional
+// guidance, and compares the outcome with the original.
+func runFork(cfg *llm.BackendConfig, path string, round int) {
+	src, err := models.LoadDiscussion(path)
+	if err != nil {
+		log.Fatalf("Error loading discussion: %v", err)
+	}
+	fmt.Printf("\n🍴 Forking %q (%d rounds, final idea: %s)\n", src.Topic, src.Round, finalTitle(src))
+
+	reader := bufio.NewReader(os.Stdin)
+	if round < 0 {
+		fmt.Printf("Fork after which round? %v (0 = after kickoff): ", src.CheckpointRounds())
+		input, _ := reader.ReadString('\n')
+		round, err = strconv.Atoi(strings.TrimSpace(input))
+		if err != nil {
+			log.Fatalf("Invalid round: %v", err)
+		}
+	}
+	fork, err := models.ForkDiscussion(src, round)
+	if err != nil {
+		log.Fatalf("Cannot fork: %v", err)
+	}
+
+	config := selectTeamConfig()
+	prepareConfig(config)
+	fmt.Print("\n🧭 Guidance for the team from here on (optional): ")
+	guidance, _ := reader.ReadString('\n')
+
+	fmt.Println()
+	printTeamComposition(config)
+	orch := orchestrator.NewConfigurableOrchestrator(cfg, config)
+	orch.OnProgress = func(message string) {
+		fmt.Println(message)
+	}
+	if err := orch.ResumeDiscussion(fork, guidance); err != nil {
+		log.Fatalf("Fork failed: %v", err)
+	}
+	src.ForkIDs = append(src.ForkIDs, fork.ID)
+
+	if html := orch.GetIdeaSheetHTML(); html != "" {
+		outputFile := filepath.Join(".", fmt.Sprintf("idea_sheet_%d.html", time.Now().Unix()))
+		if err := os.WriteFile(outputFile, []byte(html), 0644); err != nil {
+			log.Printf("Warning: Could not save idea sheet: %v", err)
+		} else {
+			fmt.Printf("📄 Idea sheet saved to: %s\n", outputFile)
+		}
+	}
+	saveDiscussion(fork)
+	exportPlan(fork)
+	// Record the new fork on the original
+	if err := models.SaveDiscussion(path, src); err != nil {
+		log.Printf("Warning: Could not update %s: %v", path, err)
+	}
+
+	printComparison(models.CompareDiscussions(src, fork))
+}
+
+// runExperiment runs a topic under each arm in spec, e.g.
+// "standard,full" or "standard@gpt-4o-mini,standard@claude-sonnet-4", and
+// saves the comparison as HTML and JSON. EXPERIMENT_BUDGET_* caps the spend
+// of all arms together.
+func runExperiment(cfg *llm.BackendConfig, spec string) {
+	var arms []models.ExperimentArm
+	for _, part := range strings.Split(spec, ",") {
+		team, model, _ := strings.Cut(strings.TrimSpace(part), "@")
+		config, ok := models.PresetTeamConfig(team)
+		if !ok {
+			log.Fatalf("Unknown team %q in --experiment (teams: standard, extended, full)", team)
+		}
+		prepareConfig(config)
+		arms = append(arms, models.ExperimentArm{Name: strings.TrimSpace(part), Config: config, Model: model})
+	}
+
+	fmt.Println("\n📝 What topic should every arm explore?")
+	fmt.Print("> ")
+	reader := bufio.NewReader(os.Stdin)
+	topic, _ := reader.ReadString('\n')
+	topic = strings.TrimSpace(topic)
+	if topic == "" {
+		log.Fatal("Topic cannot be empty")
+	}
+
+	exp := orchestrator.NewExperiment(cfg, topic, arms...)
+	exp.Budget = models.BudgetFromEnv("EXPERIMENT_BUDGET")
+	exp.OnProgress = func(arm, message string) {
+		fmt.Printf("[%s] %s\n", arm, message)
+	}
+	fmt.Printf("\n🧪 Running %d arms side by side...\n\n", len(arms))
+	res, err := exp.Run()
+	if err != nil {
+		log.Fatalf("Experiment failed: %v", err)
+	}
+
+	fmt.Println("\n" + strings.Repeat("═", 60))
+	fmt.Printf("🧪 Experiment results (baseline: %s)\n\n", res.Arms[0].Name)
+	fmt.Printf("   %-24s %-9s %-6s %-6s %-7s %-10s %-8s %s\n", "Arm", "Status", "Ideas", "Mean", "Final", "Cost", "Time", "Final idea")
+	for _, a := range res.Arms {
+		fmt.Printf("   %-24s %-9s %-6d %-6.1f %-7.1f $%-9.4f %-8s %s\n", truncateTitle(a.Name, 24), a.Status, a.Ideas, a.MeanScore,
+			a.FinalScore, a.Cost, a.Duration.Round(time.Second), truncateTitle(a.FinalIdea, 40))
+		if a.Discussion != nil {
+			saveDiscussion(a.Discussion)
+		}
+	}
+	for _, o := range res.Overlaps {
+		fmt.Printf("   %s vs %s: %d shared ideas (%.0f%% overlap)\n", o.A, o.B, o.Shared, o.Similarity*100)
+	}
+	fmt.Printf("\n   %s\n", report.ExperimentVerdict(res))
+
+	htmlFile := fmt.Sprintf("experiment_%s.html", res.ID)
+	if err := os.WriteFile(htmlFile, []byte(report.RenderExperimentHTML(res)), 0644); err != nil {
+		log.Printf("Warning: Could not save experiment report: %v", err)
+	} else {
+		fmt.Printf("\n📄 Comparison saved to: %s\n", htmlFile)
+	}
+	data, err := json.MarshalIndent(res, "", "  ")
+	if err == nil {
+		jsonFile := fmt.Sprintf("experiment_%s.json", res.ID)
+		err = os.WriteFile(jsonFile, data, 0644)
+		if err == nil {
+			fmt.Printf("💾 Comparison data saved to: %s\n", jsonFile)
+		}
+	}
+	if err != nil {
+		log.Printf("Warning: Could not save experiment data: %v", err)
+	}
+}
+
+// printComparison shows how a fork's outcome differs from the original's
+func printComparison(c *models.DiscussionComparison) {
+	fmt.Println("\n" + strings.Repeat("═", 60))
+	fmt.Printf("⚖️  Original vs fork (shared history: %d rounds)\n\n", c.SharedRounds)
+	fmt.Printf("   %-14s %-22s %-22s\n", "", "Original", "Fork")
+	fmt.Printf("   %-14s %-22d %-22d\n", "Rounds", c.A.Rounds, c.B.Rounds)
+	fmt.Printf("   %-14s %-22d %-22d\n", "Ideas", c.A.Ideas, c.B.Ideas)
+	fmt.Printf("   %-14s %-22s %-22s\n", "Final idea", truncateTitle(c.A.FinalIdea, 22), truncateTitle(c.B.FinalIdea, 22))
+	fmt.Printf("   %-14s %-22.1f %-22.1f\n", "Final score", c.A.FinalScore, c.B.FinalScore)
+	if c.SameOutcome {
+		fmt.Println("\n   Same final idea — the change did not alter the outcome.")
+	} else {
+		fmt.Println("\n   Different final idea — the change altered the outcome.")
+	}
+	for _, t := range c.OnlyInB {
+		fmt.Printf("   + %s (fork only)\n", t)
+	}
+	for _, t := range c.OnlyInA {
+		fmt.Printf("   - %s (original only)\n", t)
+	}
+	for _, sc := range c.ScoreChanges {
+		fmt.Printf("   ~ %s: %.1f → %.1f\n", sc.Title, sc.ScoreA, sc.ScoreB)
+	}
+}
+
+// finalTitle returns the final idea's title, or "none"
+func finalTitle(d *models.Discussion) string {
+	if d.FinalIdea == nil {
+		return "none"
+	}
+	return d.FinalIdea.Title
+}
+
+// truncateTitle shortens s to at most n runes
+func truncateTitle(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// drillDowns offers follow-up discussions seeded from the latest final idea,
+// then prints the drill-down tree.
+func drillDowns(reader *bufio.Reader, cfg *llm.BackendConfig, config *models.TeamConfig, root *models.Discussion) {
+	discussions := map[string]*models.Discussion{root.ID: root}
+	parent := root
+	for parent.FinalIdea != nil && askYesNo(reader, fmt.Sprintf("\n🔎 Drill down into %q? (y/N)", parent.FinalIdea.Title), false) {
+		fmt.Print("Follow-up topic (blank for \"how do we make it happen?\"): ")
+		topic, _ := reader.ReadString('\n')
+
+		orch := orchestrator.NewConfigurableOrchestrator(cfg, config)
+		orch.OnProgress = func(message string) {
+			fmt.Println(message)
+		}
+		err := orch.StartDrillDown(parent, strings.TrimSpace(topic))
+		child := orch.GetDiscussion()
+		if child != nil {
+			discussions[child.ID] = child
+			parent.ChildIDs = append(parent.ChildIDs, child.ID)
+		}
+		if err != nil {
+			log.Printf("Drill-down failed: %v", err)
+			break
+		}
+
+		if html := orch.GetIdeaSheetHTML(); html != "" {
+			outputFile := filepath.Join(".", fmt.Sprintf("idea_sheet_%d.html", time.Now().Unix()))
+			if err := os.WriteFile(outputFile, []byte(html), 0644); err != nil {
+				log.Printf("Warning: Could not save idea sheet: %v", err)
+			} else {
+				fmt.Printf("📄 Idea sheet saved to: %s\n", outputFile)
+			}
+		}
+		saveDiscussion(child)
+		exportPlan(child)
+		// Re-save the parent so its file links the new child
+		if err := models.SaveDiscussion(fmt.Sprintf("discussion_%s.json", parent.ID), parent); err != nil {
+			log.Printf("Warning: Could not update parent discussion: %v", err)
+		}
+		if child.FinalIdea != nil {
+			fmt.Printf("\n⭐ Drill-down result: %s (Score: %.1f/10)\n   %s\n", child.FinalIdea.Title, child.FinalIdea.Score, child.FinalIdea.Description)
+		}
+		parent = child
+	}
+
+	if len(discussions) > 1 {
+		tree := models.BuildDiscussionTree(root.ID, func(id string) *models.Discussion { return discussions[id] })
+		fmt.Println("\n🌳 Drill-down tree:")
+		fmt.Print(tree.Render())
+	}
+}
+
 func selectTeamConfig() *models.TeamConfig {
 	fmt.Println("🤖 Select Team Configuration:\n")
 	fmt.Println("1. Standard (4 agents, 1 round) - Quick, focused ideation")
@@ -211,6 +600,19 @@ func customTeamConfig(reader *bufio.Reader) *models.TeamConfig {
 
 	// Deep dive
 	config.DeepDive = askYesNo(reader, "Enable deep dive mode? (Y/n)", true)
+	config.AdaptiveRounds = askYesNo(reader, "Adapt round count to convergence (stop early or extend)? (y/N)", false)
+	config.Tournament = askYesNo(reader, "Rank top ideas in a head-to-head tournament? (y/N)", false)
+	if askYesNo(reader, "Score ideas with a panel of judges? (y/N)", false) {
+		config.Judges = models.DefaultJudges()
+		fmt.Print("Aggregate judge scores by mean, median or trimmed_mean [median]: ")
+		method, _ := reader.ReadString('\n')
+		method = strings.TrimSpace(method)
+		if !models.IsAggregation(method) {
+			method = models.AggregateMedian
+		}
+		config.ScoreAggregation = method
+	}
+	config.DynamicTurns = askYesNo(reader, "Let the team leader choose who speaks next each turn? (y/N)", false)
 
 	return config
 }
@@ -247,6 +649,9 @@ func printTeamComposition(config *models.TeamConfig) {
 	if config.IncludeImplementer {
 		fmt.Println("   🔧 Implementer - Plans practical execution")
 	}
+	for _, cr := range config.CustomRoles {
+		fmt.Printf("   🧩 %s - %s\n", cr.Name, cr.Persona)
+	}
 	if config.IncludeUICreator {
 		fmt.Println("   🎨 UI Creator - Generates beautiful visualizations")
 	}
@@ -254,6 +659,20 @@ func printTeamComposition(config *models.TeamConfig) {
 	fmt.Printf("\n📊 Discussion Settings:\n")
 	fmt.Printf("   Rounds: %d\n", config.MaxRounds)
 	fmt.Printf("   Deep Dive: %v\n", config.DeepDive)
+	if config.DynamicTurns {
+		fmt.Printf("   Turn-Taking: leader-directed\n")
+	}
+	if config.TeamMemory {
+		fmt.Printf("   Team Memory: %s\n", models.DefaultMemoryPath())
+	}
+	if config.StrictFailures {
+		fmt.Printf("   Strict Mode: any failed contribution stops the run\n")
+	}
+	if config.ModelAssignment == models.ModelAssignLLM {
+		fmt.Printf("   Model Assignment: chosen by the team leader\n")
+	} else {
+		fmt.Printf("   Model Assignment: by policy\n")
+	}
 	fmt.Printf("   Total Agents: %d\n", config.TeamSize())
 }
 
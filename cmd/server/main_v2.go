@@ -4,20 +4,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/yourusername/ai-agent-team/internal/agents"
 	"github.com/yourusername/ai-agent-team/internal/llmfactory"
 	"github.com/yourusername/ai-agent-team/internal/models"
 	"github.com/yourusername/ai-agent-team/internal/orchestrator"
+	"github.com/yourusername/ai-agent-team/internal/report"
 )
 
-// sessionState tracks per-discussion agent state for the War Room UI
+// sessionState tracks per-discussion agent state for the War Room UI. All
+// fields are guarded by mu except the SSE clients.
 type sessionState struct {
+	// Discussion is the latest snapshot of the discussion. Snapshots are
+	// never modified; a newer one replaces it.
 	Discussion *models.Discussion
 	Agents     map[string]*webAgentState
 	Phase      string
@@ -29,6 +36,19 @@ type sessionState struct {
 	sseClients []chan string
 
 	EvidenceCards map[string][]interface{} // role → []tools.SearchResult
+
+	// Drill-downs and forks started from this discussion on the server
+	ChildIDs []string
+	ForkIDs  []string
+}
+
+// setDiscussion stores snapshot d with the session's drill-down and fork
+// links. The caller must hold mu.
+func (ss *sessionState) setDiscussion(d *models.Discussion) {
+	linked := *d
+	linked.ChildIDs = slices.Clone(ss.ChildIDs)
+	linked.ForkIDs = slices.Clone(ss.ForkIDs)
+	ss.Discussion = &linked
 }
 
 // notifySSE sends a JSON-encoded event to all SSE subscribers (non-blocking).
@@ -93,8 +113,9 @@ var agentPersonas = map[string]struct {
 }
 
 var (
-	sessions = make(map[string]*sessionState)
-	mu       sync.RWMutex
+	sessions    = make(map[string]*sessionState)
+	experiments = make(map[string]*orchestrator.Experiment)
+	mu          sync.RWMutex
 )
 
 func main() {
@@ -103,11 +124,28 @@ func main() {
 		port = "8080"
 	}
 
+	roles, err := agents.LoadRoleDefinitions(agents.RolesDir())
+	if err != nil {
+		log.Fatalf("Error loading custom roles: %v", err)
+	}
+	customRoles = roles
+	serverBudget = models.BudgetFromEnv("DISCUSSION_BUDGET")
+	agentBudget = models.BudgetFromEnv("AGENT_BUDGET")
+	modelPolicy = models.ModelPolicyFromEnv("MODEL_POLICY")
+	modelAssignment = os.Getenv("MODEL_ASSIGNMENT")
+
 	http.HandleFunc("/", handleHome)
 	http.HandleFunc("/api/start", handleStart)
+	http.HandleFunc("/api/drilldown", handleDrillDown)
+	http.HandleFunc("/api/tree/", handleTree)
+	http.HandleFunc("/api/fork", handleFork)
+	http.HandleFunc("/api/compare/", handleCompare)
+	http.HandleFunc("/api/experiment", handleExperiment)
+	http.HandleFunc("/api/experiment/", handleExperimentResult)
 	http.HandleFunc("/api/status/", handleStatus)
 	http.HandleFunc("/api/stream/", handleStream)
 	http.HandleFunc("/api/result/", handleResult)
+	http.HandleFunc("/api/plan/", handlePlan)
 
 	fmt.Println("╔════════════════════════════════════════════════════════╗")
 	fmt.Println("║   🤖 IdeaArmy — The Idea Factory Server                ║")
@@ -118,6 +156,19 @@ func main() {
 	log.Fatal(http.ListenAndServe(":"+port, nil))
 }
 
+// customRoles are the agent roles loaded from definition files at startup;
+// they join every team.
+var customRoles []models.CustomRole
+
+// serverBudget and agentBudget are the operator's spending caps, read from
+// DISCUSSION_BUDGET_* and AGENT_BUDGET_* at startup. Requests cannot raise them.
+var serverBudget, agentBudget models.Budget
+
+// modelPolicy and modelAssignment are the operator's model assignment rules
+// and default mode, read from MODEL_POLICY_* and MODEL_ASSIGNMENT at startup.
+var modelPolicy models.ModelPolicy
+var modelAssignment string
+
 func newAgentState(role string) *webAgentState {
 	p, ok := agentPersonas[role]
 	if !ok {
@@ -134,6 +185,7 @@ func distillToDialog(role, text string) string {
 	}
 
 	// JSON content (ideation agent mostly) — extract idea titles
+	text = strings.TrimSpace(strings.TrimPrefix(text, "```json"))
 	if strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[") {
 		var titles []string
 		for _, line := range strings.Split(text, "\n") {
@@ -225,68 +277,68 @@ func stripInlineMarkdown(s string) string {
 	return result.String()
 }
 
-// parseProgress extracts agent speech and phase changes from orchestrator messages
-func parseProgress(ss *sessionState, msg string) {
-	trimmed := strings.TrimSpace(msg)
-
-	// Detect speech: "📣 [role] content"
-	if strings.Contains(trimmed, "📣") {
-		if start := strings.Index(trimmed, "["); start >= 0 {
-			if end := strings.Index(trimmed[start:], "]"); end > 0 {
-				role := trimmed[start+1 : start+end]
-				speech := strings.TrimSpace(trimmed[start+end+1:])
-				if a, ok := ss.Agents[role]; ok {
-					a.Speech = distillToDialog(role, speech)
-					a.Status = "done"
-				}
-			}
+// phaseIcons maps orchestrator phases to the War Room phase banner icon.
+var phaseIcons = map[orchestrator.Phase]string{
+	orchestrator.PhaseModelAssignment: "🔧",
+	orchestrator.PhaseKickoff:         "📋",
+	orchestrator.PhaseExploration:     "💡",
+	orchestrator.PhaseSynthesis:       "🎯",
+	orchestrator.PhaseConvergence:     "📉",
+	orchestrator.PhaseValidation:      "🔍",
+	orchestrator.PhaseDeepDive:        "🔬",
+	orchestrator.PhaseTournament:      "🏆",
+	orchestrator.PhaseSelection:       "🎯",
+	orchestrator.PhasePlanning:        "🗓️",
+	orchestrator.PhaseSummary:         "📝",
+	orchestrator.PhaseVisualization:   "🎨",
+}
+
+// applyEvent updates the War Room agent and phase state from a typed
+// orchestrator event. Callers must hold mu.
+func applyEvent(ss *sessionState, ev orchestrator.Event) {
+	switch ev.Type {
+	case orchestrator.EventPhaseStarted:
+		ss.Phase = ev.Phase.Label()
+		if ev.Phase == orchestrator.PhaseExploration {
+			ss.Phase = fmt.Sprintf("Exploration Round %d", ev.Round)
 		}
-	}
+		ss.PhaseIcon = phaseIcons[ev.Phase]
 
-	// Detect agent starting work: "🗣️ <name> contributing..."
-	if strings.Contains(trimmed, "contributing...") {
-		// Set all to idle first, mark the active one
+	case orchestrator.EventAgentStarted:
+		// Only one agent speaks at a time
 		for _, a := range ss.Agents {
 			if a.Status == "thinking" {
 				a.Status = "idle"
 			}
 		}
-		for role, a := range ss.Agents {
-			if strings.Contains(trimmed, a.Name) || strings.Contains(strings.ToLower(trimmed), role) {
-				a.Status = "thinking"
-			}
+		if a, ok := ss.Agents[ev.Role]; ok {
+			a.Status = "thinking"
+			a.Speech = ""
 		}
-	}
 
-	// Detect phase changes
-	if strings.Contains(trimmed, "Phase 1:") || strings.Contains(trimmed, "Kickoff") {
-		ss.Phase = "Team Leader Kickoff"
-		ss.PhaseIcon = "📋"
-		if a, ok := ss.Agents["team_leader"]; ok {
-			a.Status = "thinking"
+	case orchestrator.EventAgentChunk:
+		if a, ok := ss.Agents[ev.Role]; ok {
+			a.Speech += ev.Text
 		}
-	} else if strings.Contains(trimmed, "Exploration Round") {
-		ss.Phase = trimmed
-		ss.PhaseIcon = "💡"
-	} else if strings.Contains(trimmed, "Final Validation") {
-		ss.Phase = "Final Validation"
-		ss.PhaseIcon = "🔍"
-		if a, ok := ss.Agents["moderator"]; ok {
-			a.Status = "thinking"
+
+	case orchestrator.EventAgentFinished:
+		if a, ok := ss.Agents[ev.Role]; ok {
+			a.Speech = distillToDialog(ev.Role, ev.Text)
+			a.Status = "done"
 		}
-	} else if strings.Contains(trimmed, "Final Selection") {
-		ss.Phase = "Final Selection"
-		ss.PhaseIcon = "🎯"
-		if a, ok := ss.Agents["team_leader"]; ok {
-			a.Status = "thinking"
+
+	case orchestrator.EventModelAssigned:
+		if a, ok := ss.Agents[ev.Role]; ok && ev.Model != "" {
+			a.Model = ev.Model
 		}
-	} else if strings.Contains(trimmed, "Visual Idea Sheet") {
-		ss.Phase = "Creating Idea Sheet"
-		ss.PhaseIcon = "🎨"
-		if a, ok := ss.Agents["ui_creator"]; ok {
-			a.Status = "thinking"
+
+	case orchestrator.EventError:
+		if a, ok := ss.Agents[ev.Role]; ok {
+			a.Speech = fmt.Sprintf("⚠️ %s: %s", ev.Cause, ev.Text)
+			a.Status = "failed"
 		}
-	} else if strings.Contains(trimmed, "completed successfully") {
+
+	case orchestrator.EventCompleted:
 		ss.Phase = "Idea Factory Complete!"
 		ss.PhaseIcon = "🙌"
 		for _, a := range ss.Agents {
@@ -295,54 +347,21 @@ func parseProgress(ss *sessionState, msg string) {
 			}
 		}
 	}
+}
 
-	// Detect model assignments: "🔧 [role] → model"
-	if strings.Contains(trimmed, "🔧 [") && strings.Contains(trimmed, "→") {
-		idx := strings.Index(trimmed, "🔧 [")
-		if idx >= 0 {
-			rest := trimmed[idx+len("🔧 ["):]
-			if end := strings.Index(rest, "]"); end > 0 {
-				role := rest[:end]
-				model := strings.TrimSpace(rest[end+1:])
-				model = strings.TrimPrefix(model, "→")
-				model = strings.TrimSpace(model)
-				if a, ok := ss.Agents[role]; ok && model != "" {
-					a.Model = model
-				}
-			}
-		}
-	}
-
-	// Detect model assignment phase
-	if strings.Contains(trimmed, "Model Assignment") {
-		ss.Phase = "Assigning Models"
-		ss.PhaseIcon = "🔧"
-		if a, ok := ss.Agents["team_leader"]; ok {
-			a.Status = "thinking"
-		}
-	}
-
-	// Detect synthesizing
-	if strings.Contains(trimmed, "synthesizing") {
-		if a, ok := ss.Agents["team_leader"]; ok {
-			a.Status = "thinking"
-		}
-	}
-
-	ss.Log = append(ss.Log, trimmed)
-
-	// Fan-out current state snapshot to SSE subscribers
-	agents := make(map[string]*webAgentState, len(ss.Agents))
+// statusSnapshot copies the agent and phase state for an SSE "status" event.
+// Callers must hold mu.
+func statusSnapshot(ss *sessionState) map[string]interface{} {
+	agentStates := make(map[string]*webAgentState, len(ss.Agents))
 	for k, v := range ss.Agents {
 		cp := *v
-		agents[k] = &cp
+		agentStates[k] = &cp
 	}
-	ss.notifySSE("status", map[string]interface{}{
-		"agents":     agents,
+	return map[string]interface{}{
+		"agents":     agentStates,
 		"phase":      ss.Phase,
 		"phase_icon": ss.PhaseIcon,
-		"log":        trimmed,
-	})
+	}
 }
 
 func handleHome(w http.ResponseWriter, r *http.Request) {
@@ -494,6 +513,7 @@ func handleHome(w http.ResponseWriter, r *http.Request) {
             animation: glow 2s ease-in-out infinite;
         }
         .desk.done { border-color: var(--neon-mint); }
+        .desk.failed { border-color: var(--coral); }
 
         @keyframes glow {
             0%, 100% { box-shadow: 0 0 15px var(--accent-glow); }
@@ -534,6 +554,7 @@ func handleHome(w http.ResponseWriter, r *http.Request) {
         .status-idle { background: var(--bg-desk); color: var(--text-dim); }
         .status-thinking { background: rgba(255,217,61,0.15); color: var(--bright-yellow); }
         .status-done { background: rgba(81,232,152,0.15); color: var(--neon-mint); }
+        .status-failed { background: rgba(255,107,107,0.15); color: var(--coral); }
 
         .speech-bubble {
             background: var(--bg-desk);
@@ -839,6 +860,32 @@ func handleHome(w http.ResponseWriter, r *http.Request) {
             <small>Enables live web search for the Researcher agent. Get a free key at <a href="https://firecrawl.dev" target="_blank" rel="noopener" style="color:#a5b4fc;">firecrawl.dev</a>. Without it, the researcher uses LLM knowledge only.</small>
         </div>
 
+        <div class="field">
+            <label class="agent-toggle">
+                <input type="checkbox" id="chkMemory">
+                <span class="toggle-card">
+                    <span class="toggle-icon">🧠</span>
+                    <span>
+                        <span class="toggle-name">Team Memory</span>
+                        <span class="toggle-desc">Recall related past discussions so the bots build on earlier decisions instead of re-proposing rejected ideas</span>
+                    </span>
+                </span>
+            </label>
+        </div>
+
+        <div class="field">
+            <label class="agent-toggle">
+                <input type="checkbox" id="chkStrict">
+                <span class="toggle-card">
+                    <span class="toggle-icon">🛑</span>
+                    <span>
+                        <span class="toggle-name">Strict Mode</span>
+                        <span class="toggle-desc">Stop the run when any bot's contribution fails, instead of carrying on without it</span>
+                    </span>
+                </span>
+            </label>
+        </div>
+
         <div style="background:rgba(99,102,241,0.08);border-left:3px solid #6366f1;border-radius:6px;padding:14px 18px;margin-bottom:8px;font-size:0.9rem;line-height:1.6;color:#c4c9e2;">
             <strong style="color:#a5b4fc;">💡 What is this?</strong><br>
             IdeaArmy isn't here to solve your problems — it's here to generate ideas around them. Give it a statement, a challenge, or a topic, and a team of AI agents will brainstorm, debate, and surface creative possibilities you might not have considered. The more context and constraints you provide, the more focused and relevant the ideas will be. Keep it vague or wide open, and the bots will go off-the-wall — which is sometimes exactly what you need.
@@ -1093,7 +1140,7 @@ func handleHome(w http.ResponseWriter, r *http.Request) {
             desk.className = 'desk ' + agent.status;
             const badge = document.getElementById('badge-' + agent.role);
             badge.className = 'status-badge status-' + agent.status;
-            badge.textContent = agent.status === 'thinking' ? 'buzzing' : agent.status === 'done' ? 'high-five!' : 'snoozing';
+            badge.textContent = agent.status === 'thinking' ? 'buzzing' : agent.status === 'done' ? 'high-five!' : agent.status === 'failed' ? 'glitched' : 'snoozing';
 
             // Update speech
             const bubble = document.getElementById('speech-' + agent.role);
@@ -1139,7 +1186,9 @@ func handleHome(w http.ResponseWriter, r *http.Request) {
             const roles = selectedTeam === 'custom' ? getCustomAgentRoles() : TEAM_AGENTS[selectedTeam] || TEAM_AGENTS.standard;
             buildDesks(roles);
 
-            const body = { api_key: apiKey, firecrawl_key: firecrawlKey, topic: topic, team_config: selectedTeam };
+            const body = { api_key: apiKey, firecrawl_key: firecrawlKey, topic: topic, team_config: selectedTeam,
+                           team_memory: document.getElementById('chkMemory').checked,
+                           strict: document.getElementById('chkStrict').checked };
             if (selectedTeam === 'custom') {
                 body.custom = {
                     researcher:    document.getElementById('chkResearcher').checked,
@@ -1167,6 +1216,38 @@ func handleHome(w http.ResponseWriter, r *http.Request) {
             }
         }
 
+        // Follow up on the finished discussion's final idea with the same team
+        async function drillDown() {
+            const topic = prompt('Follow-up question (leave blank for "how do we make it happen?")', '');
+            if (topic === null) return;
+            const body = {
+                parent_id: discussionId,
+                api_key: document.getElementById('apiKey').value.trim(),
+                firecrawl_key: document.getElementById('firecrawlKey').value.trim(),
+                topic: topic.trim(),
+                team_config: selectedTeam
+            };
+            try {
+                const res = await fetch('/api/drilldown', {
+                    method: 'POST',
+                    headers: {'Content-Type': 'application/json'},
+                    body: JSON.stringify(body)
+                });
+                const data = await res.json();
+                if (data.error) throw new Error(data.error);
+
+                closeResult();
+                seenLogCount = 0;
+                const roles = selectedTeam === 'custom' ? getCustomAgentRoles() : TEAM_AGENTS[selectedTeam] || TEAM_AGENTS.standard;
+                buildDesks(roles);
+                discussionId = data.discussion_id;
+                document.getElementById('phaseText').textContent = '🔎 Drilling down...';
+                connectSSE(discussionId);
+            } catch(e) {
+                alert('Drill-down failed: ' + e.message);
+            }
+        }
+
         let eventSource = null;
 
         // ── Evidence Drawer ──────────────────────────────────────────
@@ -1316,7 +1397,7 @@ func handleHome(w http.ResponseWriter, r *http.Request) {
                     const pill = document.createElement('div');
                     pill.className = 'tl-event';
                     pill.style.left = pct + '%';
-                    pill.style.background = persona.color || '#888';
+                    pill.style.background = ev.type === 'error' ? 'var(--coral)' : (persona.color || '#888');
                     pill.title = ev.preview || ev.type;
                     pill.textContent = ev.preview ? ev.preview.slice(0, 30) : ev.type;
                     axis.appendChild(pill);
@@ -1441,8 +1522,9 @@ func handleHome(w http.ResponseWriter, r *http.Request) {
                     clearInterval(pollTimer);
                     pollTimer = null;
                     if (eventSource) { eventSource.close(); eventSource = null; }
+                    const failed = (data.failures || []).length;
                     document.getElementById('phaseText').innerHTML =
-                        '🙌 Bots nailed it! <button class="btn-new" onclick="showResult()">✨ See What They Built!</button> <button class="btn-new" onclick="resetToSetup()">🤖 Deploy Again!</button>';
+                        '🙌 Bots nailed it!' + (failed ? ' <span style="color:var(--coral);">⚠️ ' + failed + ' failed contribution(s)</span>' : '') + ' <button class="btn-new" onclick="showResult()">✨ See What They Built!</button> <button class="btn-new" onclick="drillDown()">🔎 Drill Down</button> <button class="btn-new" onclick="resetToSetup()">🤖 Deploy Again!</button>';
                     celebrateSparkles();
                     showResult();
                 } else if (data.status === 'failed') {
@@ -1517,36 +1599,323 @@ func handleHome(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(html))
 }
 
+// startRequest is the body of /api/start, /api/drilldown and /api/fork
+type startRequest struct {
+	ParentID     string `json:"parent_id"` // drill-down only: the discussion to follow up on
+	SourceID     string `json:"source_id"` // fork only: the discussion to branch
+	Round        int    `json:"round"`     // fork only: branch after this round (0 = after kickoff)
+	Guidance     string `json:"guidance"`  // fork only: human direction for the rest of the discussion
+	APIKey       string `json:"api_key"`
+	FirecrawlKey string `json:"firecrawl_key"`
+	Topic        string `json:"topic"`
+	TeamConfig   string `json:"team_config"`
+	TeamMemory   bool   `json:"team_memory"`      // recall related past discussions and remember this one
+	Strict       bool   `json:"strict"`           // fail the discussion when an agent contribution fails
+	ModelAssign  string `json:"model_assignment"` // "policy" or "llm" (default: the server's MODEL_ASSIGNMENT)
+	Custom       struct {
+		Researcher    bool `json:"researcher"`
+		Critic        bool `json:"critic"`
+		Implementer   bool `json:"implementer"`
+		IdeationCount int  `json:"ideation_count"`
+		MaxRounds     int  `json:"max_rounds"`
+		Adaptive      bool `json:"adaptive_rounds"`
+		Tournament    bool `json:"tournament"`
+		DynamicTurns  bool `json:"dynamic_turns"`
+		// JudgePanel scores with the default judges unless Judges lists them
+		JudgePanel       bool                 `json:"judge_panel"`
+		Judges           []models.JudgeConfig `json:"judges"`
+		ScoreAggregation string               `json:"score_aggregation"` // mean, median or trimmed_mean
+	} `json:"custom"`
+	// Budget may only tighten the server's own caps
+	Budget struct {
+		MaxTokens  int     `json:"max_tokens"`
+		MaxCost    float64 `json:"max_cost"`
+		MaxMinutes float64 `json:"max_minutes"`
+	} `json:"budget"`
+	// Criteria replace the default scoring criteria; weights default to 1
+	Criteria []models.Criterion `json:"criteria"`
+}
+
 func handleStart(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	var req struct {
-		APIKey       string `json:"api_key"`
-		FirecrawlKey string `json:"firecrawl_key"`
-		Topic        string `json:"topic"`
-		TeamConfig   string `json:"team_config"`
-		Custom       struct {
-			Researcher    bool `json:"researcher"`
-			Critic        bool `json:"critic"`
-			Implementer   bool `json:"implementer"`
-			IdeationCount int  `json:"ideation_count"`
-			MaxRounds     int  `json:"max_rounds"`
-		} `json:"custom"`
+	var req startRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		return
 	}
 
+	if req.Topic == "" {
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": "Topic is required"})
+		return
+	}
+
+	launchDiscussion(w, req, nil, nil)
+}
+
+// handleDrillDown starts a follow-up discussion seeded with a finished
+// discussion's final idea, pros and cons, evidence and open questions.
+// The topic defaults to how to realise that idea.
+func handleDrillDown(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req startRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		respondJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request"})
 		return
 	}
 
+	mu.RLock()
+	parent, exists := sessions[req.ParentID]
+	var seed *models.DiscussionSeed
+	var seedErr error
+	if exists {
+		seed, seedErr = models.NewDrillDownSeed(parent.Discussion)
+	}
+	mu.RUnlock()
+
+	if !exists {
+		respondJSON(w, http.StatusNotFound, map[string]string{"error": "Parent discussion not found"})
+		return
+	}
+	if seedErr != nil {
+		respondJSON(w, http.StatusConflict, map[string]string{"error": seedErr.Error()})
+		return
+	}
+	// The session ID is the public discussion ID
+	seed.ParentID = req.ParentID
+
+	if req.Topic == "" {
+		req.Topic = seed.DefaultTopic()
+	}
+
+	launchDiscussion(w, req, seed, nil)
+}
+
+// handleFork branches a finished discussion after a round and continues it
+// with the requested team and guidance. The fork shares the source's history
+// up to that round, so only the remaining rounds are paid for.
+func handleFork(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req startRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		return
+	}
+
+	mu.RLock()
+	source, exists := sessions[req.SourceID]
+	var fork *models.Discussion
+	var forkErr error
+	if exists {
+		if source.Discussion.Status == "running" {
+			forkErr = fmt.Errorf("discussion is still running")
+		} else {
+			fork, forkErr = models.ForkDiscussion(source.Discussion, req.Round)
+		}
+	}
+	mu.RUnlock()
+
+	if !exists {
+		respondJSON(w, http.StatusNotFound, map[string]string{"error": "Source discussion not found"})
+		return
+	}
+	if forkErr != nil {
+		respondJSON(w, http.StatusConflict, map[string]string{"error": forkErr.Error()})
+		return
+	}
+	req.Topic = fork.Topic
+
+	launchDiscussion(w, req, nil, fork)
+}
+
+// handleCompare compares two discussions, e.g. a discussion and its fork:
+// GET /api/compare/{a}/{b}
+func handleCompare(w http.ResponseWriter, r *http.Request) {
+	ids := strings.Split(strings.Trim(r.URL.Path[len("/api/compare/"):], "/"), "/")
+	if len(ids) != 2 {
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": "Usage: /api/compare/{a}/{b}"})
+		return
+	}
+
+	mu.RLock()
+	a, okA := sessions[ids[0]]
+	b, okB := sessions[ids[1]]
+	var comparison *models.DiscussionComparison
+	if okA && okB {
+		comparison = models.CompareDiscussions(a.Discussion, b.Discussion)
+	}
+	mu.RUnlock()
+
+	if comparison == nil {
+		respondJSON(w, http.StatusNotFound, map[string]string{"error": "Discussion not found"})
+		return
+	}
+	respondJSON(w, http.StatusOK, comparison)
+}
+
+// experimentRequest starts an experiment: one topic run under each arm's
+// preset team and model side by side
+type experimentRequest struct {
+	APIKey       string `json:"api_key"`
+	FirecrawlKey string `json:"firecrawl_key"`
+	Topic        string `json:"topic"`
+	Arms         []struct {
+		Name       string `json:"name"`
+		TeamConfig string `json:"team_config"` // standard, extended or full
+		Model      string `json:"model"`       // default: the backend's model
+	} `json:"arms"`
+	// Budget is shared by all arms; each discussion stays within the server's caps
+	Budget struct {
+		MaxTokens  int     `json:"max_tokens"`
+		MaxCost    float64 `json:"max_cost"`
+		MaxMinutes float64 `json:"max_minutes"`
+	} `json:"budget"`
+}
+
+// handleExperiment starts an experiment in the background. Poll
+// /api/experiment/{id} for the comparison; each arm's finished discussion is
+// also served under its own discussion ID.
+func handleExperiment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req experimentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		return
+	}
+	if req.APIKey == "" {
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": "LLM token is required — each user must provide their own"})
+		return
+	}
 	if req.Topic == "" {
 		respondJSON(w, http.StatusBadRequest, map[string]string{"error": "Topic is required"})
 		return
 	}
+	if len(req.Arms) < 2 {
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": "At least 2 arms are required"})
+		return
+	}
+
+	var arms []models.ExperimentArm
+	for _, a := range req.Arms {
+		config, ok := models.PresetTeamConfig(a.TeamConfig)
+		if !ok {
+			respondJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("unknown team_config %q (use standard, extended or full)", a.TeamConfig)})
+			return
+		}
+		config.CustomRoles = append(config.CustomRoles, customRoles...)
+		config.ModelPolicy = modelPolicy
+		config.ModelAssignment = modelAssignment
+		config.Budget = serverBudget
+		config.AgentBudget = agentBudget
+		name := a.Name
+		if name == "" {
+			name = a.TeamConfig
+			if a.Model != "" {
+				name += "@" + a.Model
+			}
+		}
+		arms = append(arms, models.ExperimentArm{Name: name, Config: config, Model: a.Model})
+	}
+
+	cfg, err := llmfactory.ResolveBackendAuto(req.APIKey)
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("Failed to resolve LLM backend: %v", err)})
+		return
+	}
+
+	exp := orchestrator.NewExperiment(cfg, req.Topic, arms...)
+	exp.ID = uuid.New().String()
+	exp.FirecrawlKey = req.FirecrawlKey
+	exp.Budget = models.Budget{
+		MaxTokens:   req.Budget.MaxTokens,
+		MaxCost:     req.Budget.MaxCost,
+		MaxDuration: time.Duration(req.Budget.MaxMinutes * float64(time.Minute)),
+	}
+	exp.OnProgress = func(arm, message string) {
+		log.Printf("[%s] %s", arm, strings.TrimSpace(message))
+	}
+	if err := exp.Validate(); err != nil {
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	mu.Lock()
+	experiments[exp.ID] = exp
+	mu.Unlock()
+
+	go func() {
+		res, err := exp.Run()
+		if err != nil {
+			log.Printf("Experiment %s failed: %v", exp.ID, err)
+			return
+		}
+		// Serve each arm's discussion like any other
+		mu.Lock()
+		defer mu.Unlock()
+		for _, a := range res.Arms {
+			if a.Discussion != nil {
+				ss := &sessionState{
+					Agents:    make(map[string]*webAgentState),
+					Phase:     "Complete",
+					PhaseIcon: "🧪",
+				}
+				ss.setDiscussion(a.Discussion)
+				sessions[a.DiscussionID] = ss
+			}
+		}
+	}()
+
+	respondJSON(w, http.StatusOK, map[string]string{"experiment_id": exp.ID})
+}
+
+// handleExperimentResult returns an experiment's comparison as JSON, or as a
+// standalone HTML page with ?format=html. Arms still running are reported
+// with status "running".
+func handleExperimentResult(w http.ResponseWriter, r *http.Request) {
+	id := strings.Trim(r.URL.Path[len("/api/experiment/"):], "/")
+
+	mu.RLock()
+	exp, exists := experiments[id]
+	mu.RUnlock()
+
+	if !exists {
+		respondJSON(w, http.StatusNotFound, map[string]string{"error": "Experiment not found"})
+		return
+	}
+	res := exp.Result()
+	if res == nil {
+		// Run has not started yet
+		res = &models.ExperimentResult{ID: id, Topic: exp.Topic, Status: "pending"}
+	}
+
+	if r.URL.Query().Get("format") == "html" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, report.RenderExperimentHTML(res))
+		return
+	}
+	respondJSON(w, http.StatusOK, res)
+}
 
+// launchDiscussion builds the team, registers the session and runs the
+// discussion in the background. A non-nil seed makes it a drill-down of the
+// session seed.ParentID, and a non-nil fork continues a branch of the session
+// fork.ForkOf; either way the new discussion is linked from that session.
+func launchDiscussion(w http.ResponseWriter, req startRequest, seed *models.DiscussionSeed, fork *models.Discussion) {
 	if req.APIKey == "" {
 		respondJSON(w, http.StatusBadRequest, map[string]string{"error": "LLM token is required — each user must provide their own"})
 		return
@@ -1574,6 +1943,15 @@ func handleStart(w http.ResponseWriter, r *http.Request) {
 		if ideationCount > 3 {
 			ideationCount = 3
 		}
+		aggregation := req.Custom.ScoreAggregation
+		if aggregation != "" && !models.IsAggregation(aggregation) {
+			respondJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("unknown score_aggregation %q (use %q, %q or %q)", aggregation, models.AggregateMean, models.AggregateMedian, models.AggregateTrimmedMean)})
+			return
+		}
+		judges := req.Custom.Judges
+		if len(judges) == 0 && req.Custom.JudgePanel {
+			judges = models.DefaultJudges()
+		}
 		config = &models.TeamConfig{
 			IncludeTeamLeader:  true,
 			IncludeUICreator:   true,
@@ -1586,12 +1964,44 @@ func handleStart(w http.ResponseWriter, r *http.Request) {
 			IdeationCount:      ideationCount,
 			MinIdeas:           3,
 			DeepDive:           rounds > 1,
+			DedupIdeas:         true,
+			AdaptiveRounds:     req.Custom.Adaptive,
+			Tournament:         req.Custom.Tournament,
+			DynamicTurns:       req.Custom.DynamicTurns,
+			Judges:             judges,
+			ScoreAggregation:   aggregation,
 			MinScoreThreshold:  6.0,
 		}
 	default:
 		config = models.StandardTeamConfig()
 	}
 
+	config.CustomRoles = append(config.CustomRoles, customRoles...)
+	config.TeamMemory = req.TeamMemory
+	config.StrictFailures = req.Strict
+	criteria, err := models.NormalizeCriteria(req.Criteria)
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	config.Criteria = criteria
+	config.ModelPolicy = modelPolicy
+	config.ModelAssignment = modelAssignment
+	switch req.ModelAssign {
+	case "":
+	case models.ModelAssignPolicy, models.ModelAssignLLM:
+		config.ModelAssignment = req.ModelAssign
+	default:
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("unknown model_assignment %q (use %q or %q)", req.ModelAssign, models.ModelAssignPolicy, models.ModelAssignLLM)})
+		return
+	}
+	config.Budget = serverBudget.Min(models.Budget{
+		MaxTokens:   req.Budget.MaxTokens,
+		MaxCost:     req.Budget.MaxCost,
+		MaxDuration: time.Duration(req.Budget.MaxMinutes * float64(time.Minute)),
+	})
+	config.AgentBudget = agentBudget
+
 	cfg, err := llmfactory.ResolveBackendAuto(req.APIKey)
 	if err != nil {
 		respondJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("Failed to resolve LLM backend: %v", err)})
@@ -1606,7 +2016,14 @@ func handleStart(w http.ResponseWriter, r *http.Request) {
 	// Build agent states for this team
 	agentStates := make(map[string]*webAgentState)
 	for _, role := range config.GetActiveAgentRoles() {
-		agentStates[string(role)] = newAgentState(string(role))
+		state := newAgentState(string(role))
+		if cr, ok := config.CustomRole(role); ok {
+			state.Name = cr.Name
+			if cr.Persona != "" {
+				state.Tagline = cr.Persona
+			}
+		}
+		agentStates[string(role)] = state
 	}
 
 	// Create session state upfront so SSE clients can connect immediately
@@ -1616,24 +2033,34 @@ func handleStart(w http.ResponseWriter, r *http.Request) {
 		PhaseIcon: "⚡",
 	}
 
-	// Wire up progress callback to parse agent updates and fan-out to SSE
+	// Log lines feed the activity feed
 	orch.OnProgress = func(message string) {
 		log.Println(message)
+		trimmed := strings.TrimSpace(message)
 		mu.Lock()
-		parseProgress(ss, message)
+		ss.Log = append(ss.Log, trimmed)
+		snapshot := statusSnapshot(ss)
 		mu.Unlock()
+		snapshot["log"] = trimmed
+		ss.notifySSE("status", snapshot)
 	}
 
-	// Wire up streaming chunk callback for real-time token delivery via SSE
-	orch.OnChunk = func(role, chunk string) {
-		ss.notifySSE("chunk", map[string]string{"role": role, "chunk": chunk})
-		// Also update the agent speech buffer in session state
-		mu.Lock()
-		if a, ok := ss.Agents[role]; ok {
-			a.Speech += chunk
+	// Typed events drive agent desks and the phase banner
+	orch.Subscribe(func(ev orchestrator.Event) {
+		if ev.Type == orchestrator.EventAgentChunk {
+			// Real-time token delivery; the desk snapshot follows on the next status event
+			ss.notifySSE("chunk", map[string]string{"role": ev.Role, "chunk": ev.Text})
+			mu.Lock()
+			applyEvent(ss, ev)
+			mu.Unlock()
+			return
 		}
+		mu.Lock()
+		applyEvent(ss, ev)
+		snapshot := statusSnapshot(ss)
 		mu.Unlock()
-	}
+		ss.notifySSE("status", snapshot)
+	})
 
 	// Wire up evidence callback to capture researcher search results
 	orch.OnEvidence = func(role string, results []interface{}) {
@@ -1650,28 +2077,62 @@ func handleStart(w http.ResponseWriter, r *http.Request) {
 	// Pre-generate a discussion ID and register session immediately
 	// so SSE clients can connect before the discussion fully initializes.
 	sessionID := uuid.New().String()
-	ss.Discussion = &models.Discussion{
+	orch.DiscussionID = sessionID
+	orch.Seed = seed
+	placeholder := &models.Discussion{
 		ID:     sessionID,
 		Topic:  req.Topic,
 		Status: "running",
+		Seed:   seed,
+	}
+	if seed != nil {
+		placeholder.ParentID = seed.ParentID
 	}
+	if fork != nil {
+		placeholder.ForkOf, placeholder.ForkRound = fork.ForkOf, fork.ForkRound
+	}
+	ss.Discussion = placeholder
+
+	// Readers only ever see snapshots of the running discussion
+	orch.Watch(func(d *models.Discussion) {
+		mu.Lock()
+		ss.setDiscussion(d)
+		mu.Unlock()
+	})
+
 	mu.Lock()
 	sessions[sessionID] = ss
+	if seed != nil {
+		if parent, ok := sessions[seed.ParentID]; ok {
+			parent.ChildIDs = append(parent.ChildIDs, sessionID)
+			parent.setDiscussion(parent.Discussion)
+		}
+	}
+	if fork != nil {
+		if source, ok := sessions[fork.ForkOf]; ok {
+			source.ForkIDs = append(source.ForkIDs, sessionID)
+			source.setDiscussion(source.Discussion)
+		}
+	}
 	mu.Unlock()
 
 	// Start discussion in background
 	go func() {
-		if err := orch.StartDiscussion(req.Topic); err != nil {
-			log.Printf("Discussion failed: %v", err)
-			mu.Lock()
-			ss.Discussion.Status = "failed"
-			mu.Unlock()
+		start := func() error { return orch.StartDiscussion(req.Topic) }
+		if fork != nil {
+			start = func() error { return orch.ResumeDiscussion(fork, req.Guidance) }
 		}
-		// Update session with final discussion object
-		if d := orch.GetDiscussion(); d != nil {
-			mu.Lock()
-			ss.Discussion = d
-			mu.Unlock()
+		// The watcher stores the final snapshot; only a run that fails
+		// before it begins leaves the placeholder to mark as failed
+		if err := start(); err != nil {
+			log.Printf("Discussion failed: %v", err)
+			if orch.Snapshot() == nil {
+				mu.Lock()
+				failed := *ss.Discussion
+				failed.Status = "failed"
+				ss.setDiscussion(&failed)
+				mu.Unlock()
+			}
 		}
 	}()
 
@@ -1680,11 +2141,32 @@ func handleStart(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// sessionDiscussion returns the latest snapshot of a session's discussion
+func sessionDiscussion(id string) (*models.Discussion, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	ss, ok := sessions[id]
+	if !ok {
+		return nil, false
+	}
+	return ss.Discussion, true
+}
+
 func handleStatus(w http.ResponseWriter, r *http.Request) {
 	id := r.URL.Path[len("/api/status/"):]
 
 	mu.RLock()
 	ss, exists := sessions[id]
+	var d *models.Discussion
+	var state map[string]interface{}
+	var logLines []string
+	var evidence map[string][]interface{}
+	if exists {
+		d = ss.Discussion
+		state = statusSnapshot(ss)
+		logLines = slices.Clone(ss.Log)
+		evidence = maps.Clone(ss.EvidenceCards)
+	}
 	mu.RUnlock()
 
 	if !exists {
@@ -1698,14 +2180,14 @@ func handleStatus(w http.ResponseWriter, r *http.Request) {
 		Score float64 `json:"score"`
 	}
 	ideas := make([]ideaInfo, 0)
-	for _, idea := range ss.Discussion.Ideas {
+	for _, idea := range d.Ideas {
 		ideas = append(ideas, ideaInfo{Title: idea.Title, Score: idea.Score})
 	}
 
 	// Final idea title
 	finalIdea := ""
-	if ss.Discussion.FinalIdea != nil {
-		finalIdea = ss.Discussion.FinalIdea.Title
+	if d.FinalIdea != nil {
+		finalIdea = d.FinalIdea.Title
 	}
 
 	// Build slim message list for swimlane (from, type, timestamp, content preview)
@@ -1715,8 +2197,8 @@ func handleStatus(w http.ResponseWriter, r *http.Request) {
 		Timestamp time.Time `json:"timestamp"`
 		Preview   string    `json:"preview"`
 	}
-	msgs := make([]msgInfo, 0, len(ss.Discussion.Messages))
-	for _, m := range ss.Discussion.Messages {
+	msgs := make([]msgInfo, 0, len(d.Messages))
+	for _, m := range d.Messages {
 		preview := m.Content
 		if len(preview) > 120 {
 			preview = preview[:120] + "…"
@@ -1725,46 +2207,74 @@ func handleStatus(w http.ResponseWriter, r *http.Request) {
 	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"status":     ss.Discussion.Status,
-		"phase":      ss.Phase,
-		"phase_icon": ss.PhaseIcon,
-		"agents":     ss.Agents,
-		"ideas":      ideas,
-		"final_idea": finalIdea,
-		"round":      ss.Discussion.Round,
-		"log":        ss.Log,
-		"messages":   msgs,
-		"evidence":   ss.EvidenceCards,
-		"start_time": ss.Discussion.StartTime,
+		"status":          d.Status,
+		"phase":           state["phase"],
+		"phase_icon":      state["phase_icon"],
+		"agents":          state["agents"],
+		"ideas":           ideas,
+		"final_idea":      finalIdea,
+		"round":           d.Round,
+		"log":             logLines,
+		"messages":        msgs,
+		"evidence":        evidence,
+		"start_time":      d.StartTime,
+		"budget":          d.Budget,
+		"parent_id":       d.ParentID,
+		"child_ids":       d.ChildIDs,
+		"fork_of":         d.ForkOf,
+		"fork_ids":        d.ForkIDs,
+		"checkpoints":     d.CheckpointRounds(),
+		"summary":         d.Summary,
+		"round_summaries": d.RoundSummaries,
+		"metrics":         d.Metrics,
+		"failures":        d.Failures,
 	})
 }
 
-func handleResult(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Path[len("/api/result/"):]
+// handleTree returns the drill-down tree containing a discussion, rooted at
+// its oldest ancestor.
+func handleTree(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Path[len("/api/tree/"):]
 
 	mu.RLock()
-	ss, exists := sessions[id]
+	tree := models.BuildDiscussionTree(id, func(id string) *models.Discussion {
+		if ss, ok := sessions[id]; ok {
+			return ss.Discussion
+		}
+		return nil
+	})
 	mu.RUnlock()
 
+	if tree == nil {
+		respondJSON(w, http.StatusNotFound, map[string]string{"error": "Discussion not found"})
+		return
+	}
+	respondJSON(w, http.StatusOK, tree)
+}
+
+func handleResult(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Path[len("/api/result/"):]
+
+	d, exists := sessionDiscussion(id)
 	if !exists {
 		respondJSON(w, http.StatusNotFound, map[string]string{"error": "Discussion not found"})
 		return
 	}
 
 	var html string
-	var conceptMap string
-	for _, msg := range ss.Discussion.Messages {
+	var sections string
+	for _, msg := range d.Messages {
 		switch msg.Type {
 		case "visualization":
 			html = msg.Content
-		case "concept_map":
-			conceptMap = msg.Content
+		case "summary_section", "score_table", "risk_register", "plan_section", "concept_map", "metrics_section":
+			sections += msg.Content
 		}
 	}
-	// If the concept map was injected into the idea sheet it's already in html.
-	// conceptMap is only set when visualization wasn't available.
-	if html == "" && conceptMap != "" {
-		html = conceptMap
+	// If the report sections were injected into the idea sheet
+	// they're already in html. sections is only set when visualization wasn't available.
+	if html == "" && sections != "" {
+		html = sections
 	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
@@ -1772,6 +2282,52 @@ func handleResult(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handlePlan exports the final idea's implementation plan as JSON (default),
+// a Mermaid gantt chart (?format=mermaid, starting today or on ?start=YYYY-MM-DD)
+// or issue-tracker CSV (?format=csv).
+func handlePlan(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Path[len("/api/plan/"):]
+
+	d, exists := sessionDiscussion(id)
+	if !exists {
+		respondJSON(w, http.StatusNotFound, map[string]string{"error": "Discussion not found"})
+		return
+	}
+	final := d.FinalIdea
+	if final == nil || final.Plan == nil {
+		respondJSON(w, http.StatusNotFound, map[string]string{"error": "No implementation plan for this discussion"})
+		return
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		respondJSON(w, http.StatusOK, final.Plan)
+	case "mermaid":
+		start := time.Now()
+		if s := r.URL.Query().Get("start"); s != "" {
+			t, err := time.Parse("2006-01-02", s)
+			if err != nil {
+				respondJSON(w, http.StatusBadRequest, map[string]string{"error": "start must be a YYYY-MM-DD date"})
+				return
+			}
+			start = t
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, report.PlanToMermaidGantt(final.Title, final.Plan, start))
+	case "csv":
+		issues, err := report.PlanToIssuesCSV(final.Plan)
+		if err != nil {
+			respondJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=issues_%s.csv", id))
+		w.Write(issues)
+	default:
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": "format must be json, mermaid or csv"})
+	}
+}
+
 // handleStream provides a Server-Sent Events stream for real-time updates.
 // Clients connect here instead of (or in addition to) polling /api/status/.
 func handleStream(w http.ResponseWriter, r *http.Request) {
@@ -1810,20 +2366,11 @@ func handleStream(w http.ResponseWriter, r *http.Request) {
 
 	// Send current state immediately so a late-joining client catches up
 	mu.RLock()
-	agents := make(map[string]*webAgentState, len(ss.Agents))
-	for k, v := range ss.Agents {
-		cp := *v
-		agents[k] = &cp
-	}
-	phase, phaseIcon := ss.Phase, ss.PhaseIcon
+	snapshot := statusSnapshot(ss)
 	mu.RUnlock()
 
-	initial, _ := json.Marshal(map[string]interface{}{
-		"type":       "status",
-		"agents":     agents,
-		"phase":      phase,
-		"phase_icon": phaseIcon,
-	})
+	snapshot["type"] = "status"
+	initial, _ := json.Marshal(snapshot)
 	fmt.Fprintf(w, "data: %s\n\n", initial)
 	flusher.Flush()
 
@@ -2,6 +2,7 @@ package agents
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/yourusername/ai-agent-team/internal/llm"
 	"github.com/yourusername/ai-agent-team/internal/models"
@@ -50,6 +51,18 @@ func (a *BaseAgent) GetSystemPrompt() string { return a.SystemPrompt }
 // GetModel returns the LLM model identifier this agent is using
 func (a *BaseAgent) GetModel() string { return a.Model }
 
+// SetOnChunk sets the streaming token callback (nil to disable streaming)
+func (a *BaseAgent) SetOnChunk(fn func(string)) { a.OnChunk = fn }
+
+// SetNotify sets the status message callback
+func (a *BaseAgent) SetNotify(fn func(string)) { a.Notify = fn }
+
+// SetModel records the LLM model identifier this agent is using
+func (a *BaseAgent) SetModel(model string) { a.Model = model }
+
+// SetFirecrawlKey sets the per-request Firecrawl API key for web search
+func (a *BaseAgent) SetFirecrawlKey(key string) { a.FirecrawlKey = key }
+
 // RegisterTool registers a tool and its executor for this agent.
 func (a *BaseAgent) RegisterTool(def llm.ToolDefinition, executor func(args string) (string, error)) {
 	// Replace existing tool with same name to prevent duplicate declarations
@@ -120,6 +133,21 @@ func (a *BaseAgent) QueryWithTools(query string) (string, error) {
 	return a.QueryStream(query)
 }
 
+// maxContextRisks caps the risks listed per idea in the shared context
+const maxContextRisks = 3
+
+// formatRisk renders a risk register entry on one line
+func formatRisk(r models.Risk) string {
+	line := fmt.Sprintf("- [%s severity, %s likelihood] %s: %s", r.Severity, r.Likelihood, r.Category, r.Description)
+	if r.Mitigation != "" {
+		line += " | Mitigation: " + r.Mitigation
+	}
+	if r.OpenQuestion != "" {
+		line += " | Open question: " + r.OpenQuestion
+	}
+	return line
+}
+
 // BuildContext creates a context string from the discussion history
 func BuildContext(discussion *models.Discussion) string {
 	if discussion == nil {
@@ -127,6 +155,9 @@ func BuildContext(discussion *models.Discussion) string {
 	}
 
 	context := fmt.Sprintf("Topic: %s\n\n", discussion.Topic)
+	if discussion.Seed != nil {
+		context += discussion.Seed.Context() + "\n"
+	}
 
 	if len(discussion.Messages) > 0 {
 		context += "Previous Discussion:\n"
@@ -137,17 +168,73 @@ func BuildContext(discussion *models.Discussion) string {
 	}
 
 	if len(discussion.Ideas) > 0 {
-		context += "Current Ideas:\n"
+		dropped := discussion.DroppedIdeas()
+		context += "Current Ideas (reference them by the handle in brackets, e.g. I1):\n"
 		for i, idea := range discussion.Ideas {
-			context += fmt.Sprintf("%d. %s - %s\n", i+1, idea.Title, idea.Description)
+			context += fmt.Sprintf("[%s] %s - %s\n", discussion.IdeaHandle(i), idea.Title, idea.Description)
+			if dropped[idea.ID] {
+				context += "   Set aside by the team leader\n"
+			}
+			if parents := parentTitles(discussion, idea); len(parents) > 0 {
+				context += fmt.Sprintf("   Builds on: %s (version %d, round %d)\n", strings.Join(parents, "; "), len(idea.Revisions)+1, idea.Round)
+			}
 			if idea.Validated {
 				context += fmt.Sprintf("   Score: %.1f/10\n", idea.Score)
+				if len(idea.CriterionScores) > 0 {
+					context += fmt.Sprintf("   Criteria: %s\n", formatCriterionScores(discussion, idea))
+				}
+			}
+			if idea.Disputed {
+				context += fmt.Sprintf("   Judges disagree on this idea (%d judges, score variance %.1f)\n", len(idea.JudgeScores), idea.ScoreVariance)
+			}
+			if idea.Rating > 0 {
+				context += fmt.Sprintf("   Tournament rating: %.0f (%d wins, %d losses)\n", idea.Rating, idea.Wins, idea.Losses)
+			}
+			if len(idea.MergedFrom) > 0 {
+				var aliases []string
+				for _, m := range idea.MergedFrom {
+					aliases = append(aliases, m.Title)
+				}
+				context += fmt.Sprintf("   Also proposed as: %s\n", strings.Join(aliases, "; "))
+			}
+			if len(idea.Risks) > 0 {
+				context += fmt.Sprintf("   Risks (%d raised):\n", len(idea.Risks))
+				for j, r := range models.SortRisks(idea.Risks) {
+					if j == maxContextRisks {
+						break
+					}
+					context += "   " + formatRisk(r) + "\n"
+				}
+			}
+			if p := idea.Plan; p != nil {
+				context += fmt.Sprintf("   Implementation plan: %d phases, %d tasks, %.0f weeks\n", len(p.Phases), len(p.Tasks()), p.TotalWeeks())
+			}
+			if idea.DeepDive != nil {
+				context += "   Deep dive: evidence, risks and plan gathered (see deep_dive messages)\n"
 			}
 		}
 		context += "\n"
 	}
 
+	if s := discussion.LatestRoundSummary(); s != nil && s.Focus != "" {
+		context += fmt.Sprintf("Team leader's focus after round %d: %s\n\n", s.Round, s.Focus)
+	}
+
 	return context
 }
 
+// parentTitles returns the titles of the ideas an idea builds on
+func parentTitles(discussion *models.Discussion, idea models.Idea) []string {
+	var titles []string
+	for _, pid := range idea.ParentIDs {
+		for _, other := range discussion.Ideas {
+			if other.ID == pid {
+				titles = append(titles, other.Title)
+				break
+			}
+		}
+	}
+	return titles
+}
+
 // Agent interface defines the common behavior for all agents
@@ -1,9 +1,11 @@
 package agents
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/yourusername/ai-agent-team/internal/llm"
 	"github.com/yourusername/ai-agent-team/internal/models"
+	"strings"
 )
 
 // CriticAgent challenges assumptions and identifies weaknesses
@@ -38,7 +40,22 @@ When responding:
 - Suggest what needs to be addressed
 - Be constructive - the goal is improvement
 
-Your criticism should make ideas stronger, not just tear them down.`
+Your criticism should make ideas stronger, not just tear them down.
+
+After your analysis, record each concrete risk in a JSON risk register:
+{
+  "risks": [
+    {
+      "idea_id": "handle of the idea the risk applies to, e.g. I2",
+      "category": "market | technical | legal | financial | operational | ethical | adoption",
+      "description": "What could go wrong",
+      "severity": "low | medium | high",
+      "likelihood": "low | medium | high",
+      "mitigation": "How the risk could be reduced",
+      "open_question": "The question the idea must answer"
+    }
+  ]
+}`
 
 	return &CriticAgent{
 		BaseAgent: &BaseAgent{
@@ -59,7 +76,8 @@ func (a *CriticAgent) Process(context *models.Discussion, input string) (*models
 
 Task: %s
 
-Challenge assumptions and identify potential weaknesses. Ask tough questions that need answers.`,
+Challenge assumptions and identify potential weaknesses. Ask tough questions that need answers.
+End with the JSON risk register, one entry per concrete risk, referencing ideas by their handle.`,
 		discussionContext, input)
 
 	response, err := a.QueryStream(query)
@@ -70,5 +88,59 @@ Challenge assumptions and identify potential weaknesses. Ask tough questions tha
 	return &models.AgentResponse{
 		AgentRole: a.Role,
 		Content:   response,
+		Risks:     a.extractRisks(response, context),
 	}, nil
 }
+
+// extractRisks parses the risk register from the response, attributing each
+// risk to the idea it references. Risks that match no idea are dropped.
+func (a *CriticAgent) extractRisks(response string, discussion *models.Discussion) []models.IdeaRisk {
+	startIdx := strings.Index(response, "{")
+	endIdx := strings.LastIndex(response, "}")
+	if startIdx == -1 || endIdx == -1 || discussion == nil {
+		return nil
+	}
+
+	var parsed struct {
+		Risks []struct {
+			IdeaID       string `json:"idea_id"`
+			Category     string `json:"category"`
+			Description  string `json:"description"`
+			Severity     string `json:"severity"`
+			Likelihood   string `json:"likelihood"`
+			Mitigation   string `json:"mitigation"`
+			OpenQuestion string `json:"open_question"`
+		} `json:"risks"`
+	}
+	if err := json.Unmarshal([]byte(response[startIdx:endIdx+1]), &parsed); err != nil {
+		return nil
+	}
+
+	var risks []models.IdeaRisk
+	for _, r := range parsed.Risks {
+		if strings.TrimSpace(r.Description) == "" {
+			continue
+		}
+		i := findIdea(discussion, r.IdeaID, "", nil)
+		if i < 0 {
+			// A focused discussion (deep dive) only shows one idea
+			if len(discussion.Ideas) != 1 {
+				continue
+			}
+			i = 0
+		}
+		risks = append(risks, models.IdeaRisk{
+			IdeaID: discussion.Ideas[i].ID,
+			Risk: models.Risk{
+				Category:     strings.ToLower(strings.TrimSpace(r.Category)),
+				Description:  strings.TrimSpace(r.Description),
+				Severity:     models.NormalizeRiskLevel(r.Severity),
+				Likelihood:   models.NormalizeRiskLevel(r.Likelihood),
+				Mitigation:   strings.TrimSpace(r.Mitigation),
+				OpenQuestion: strings.TrimSpace(r.OpenQuestion),
+				RaisedBy:     string(a.Role),
+			},
+		})
+	}
+	return risks
+}
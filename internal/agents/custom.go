@@ -0,0 +1,133 @@
+package agents
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/ai-agent-team/internal/llm"
+	"github.com/yourusername/ai-agent-team/internal/models"
+	"github.com/yourusername/ai-agent-team/internal/tools"
+)
+
+// CustomAgent is an agent whose role is defined in a definition file
+type CustomAgent struct {
+	*BaseAgent
+	Definition models.CustomRole
+}
+
+// NewCustomAgent creates an agent from a custom role definition
+func NewCustomAgent(client llm.Client, def models.CustomRole) *CustomAgent {
+	return &CustomAgent{
+		BaseAgent: &BaseAgent{
+			Role:         def.Role,
+			Name:         def.Name,
+			SystemPrompt: def.SystemPrompt,
+			Client:       client,
+			Temperature:  def.Temperature,
+		},
+		Definition: def,
+	}
+}
+
+// Process runs the custom role's task, using web search if the definition
+// allows it and a key is configured, and extracting ideas when the output
+// schema asks for them
+func (a *CustomAgent) Process(context *models.Discussion, input string) (*models.AgentResponse, error) {
+	query := fmt.Sprintf(`%s
+
+Task: %s`, BuildContext(context), input)
+	if a.Definition.OutputSchema != "" {
+		query += "\n\nReturn your response as JSON matching this format:\n" + a.Definition.OutputSchema
+	}
+
+	var captured []tools.SearchResult
+	if a.usesWebSearch() {
+		a.RegisterTool(tools.WebSearchTool(), tools.WebSearchExecutor(
+			a.FirecrawlKey,
+			func(msg string) {
+				if a.Notify != nil {
+					a.Notify(fmt.Sprintf("  📣 [%s] %s", a.Role, msg))
+				}
+			},
+			func(results []tools.SearchResult) {
+				captured = append(captured, results...)
+			},
+		))
+	}
+
+	response, err := a.QueryWithTools(query)
+	if err != nil {
+		return nil, fmt.Errorf("%s query failed: %w", a.Role, err)
+	}
+
+	var searchResults []interface{}
+	for _, r := range captured {
+		searchResults = append(searchResults, r)
+	}
+
+	return &models.AgentResponse{
+		AgentRole:     a.Role,
+		Content:       response,
+		Ideas:         a.extractIdeas(response, context),
+		SearchResults: searchResults,
+	}, nil
+}
+
+// PhasePrompt returns the task prompt for a phase the role joins
+func (a *CustomAgent) PhasePrompt(phase string) (string, bool) {
+	prompt, ok := a.Definition.Phases[phase]
+	return prompt, ok
+}
+
+// usesWebSearch reports whether the role may search and a Firecrawl key is available
+func (a *CustomAgent) usesWebSearch() bool {
+	for _, t := range a.Definition.Tools {
+		if t == "web_search" {
+			return a.FirecrawlKey != "" || os.Getenv("FIRECRAWL_API_KEY") != ""
+		}
+	}
+	return false
+}
+
+// extractIdeas parses an "ideas" array from structured output, if the role has one
+func (a *CustomAgent) extractIdeas(response string, discussion *models.Discussion) []models.Idea {
+	if a.Definition.OutputSchema == "" {
+		return nil
+	}
+	startIdx := strings.Index(response, "{")
+	endIdx := strings.LastIndex(response, "}")
+	if startIdx == -1 || endIdx == -1 {
+		return nil
+	}
+
+	var parsed struct {
+		Ideas []struct {
+			Title       string   `json:"title"`
+			Description string   `json:"description"`
+			Category    string   `json:"category"`
+			BuildsOn    []string `json:"builds_on"`
+		} `json:"ideas"`
+	}
+	if err := json.Unmarshal([]byte(response[startIdx:endIdx+1]), &parsed); err != nil {
+		return nil
+	}
+
+	var ideas []models.Idea
+	for _, idea := range parsed.Ideas {
+		if idea.Title == "" {
+			continue
+		}
+		ideas = append(ideas, models.Idea{
+			ID:          uuid.New().String(),
+			Title:       idea.Title,
+			Description: idea.Description,
+			Category:    idea.Category,
+			CreatedBy:   string(a.Role),
+			ParentIDs:   resolveIdeaRefs(discussion, idea.BuildsOn),
+		})
+	}
+	return ideas
+}
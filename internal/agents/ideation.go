@@ -23,7 +23,7 @@ Your responsibilities:
 - Research and reference existing knowledge, trends, and best practices
 - Think deeply about concepts from multiple angles
 - Explore unconventional approaches and solutions
-- Build upon previous ideas in the discussion
+- Build upon previous ideas in the discussion, citing the IDs of the ideas you refine
 - Provide detailed explanations for each idea
 
 Your approach:
@@ -39,7 +39,8 @@ When generating ideas, structure them as JSON with:
     {
       "title": "Brief catchy title",
       "description": "Detailed description explaining the concept",
-      "category": "Category or domain of the idea"
+      "category": "Category or domain of the idea",
+      "builds_on": ["handle (e.g. I2) of each existing idea this refines or combines (empty for a brand-new idea)"]
     }
   ]
 }
@@ -59,13 +60,13 @@ Be creative, thorough, and insightful. Quality over quantity - each idea should
 
 // Process generates ideas based on input
 func (a *IdeationAgent) Process(context *models.Discussion, input string) (*models.AgentResponse, error) {
-	discussionContext := BuildContext(context)
+	discussionContext := BuildContext(context) + context.MemoryContext()
 
 	query := fmt.Sprintf(`%s
 
 Task: %s
 
-Generate 3-5 creative, well-researched ideas. Think deeply about the concepts, their validity, and potential impact. Return your response as JSON following the specified format.`,
+Generate 3-5 creative, well-researched ideas. Think deeply about the concepts, their validity, and potential impact. When an idea refines or combines existing ideas, list their handles in "builds_on". Return your response as JSON following the specified format.`,
 		discussionContext, input)
 
 	response, err := a.QueryStream(query)
@@ -99,9 +100,10 @@ func (a *IdeationAgent) extractIdeas(response string, discussion *models.Discuss
 
 	var parsed struct {
 		Ideas []struct {
-			Title       string `json:"title"`
-			Description string `json:"description"`
-			Category    string `json:"category"`
+			Title       string   `json:"title"`
+			Description string   `json:"description"`
+			Category    string   `json:"category"`
+			BuildsOn    []string `json:"builds_on"`
 		} `json:"ideas"`
 	}
 
@@ -119,8 +121,51 @@ func (a *IdeationAgent) extractIdeas(response string, discussion *models.Discuss
 			CreatedBy:   string(a.Role),
 			Validated:   false,
 			Score:       0,
+			ParentIDs:   resolveIdeaRefs(discussion, idea.BuildsOn),
 		})
 	}
 
 	return ideas
 }
+
+// resolveIdeaRefs maps the idea references an agent cited (full or short IDs,
+// or titles) to the IDs of existing ideas, dropping any it cannot resolve.
+// References to duplicates that were merged away resolve to the surviving idea.
+func resolveIdeaRefs(discussion *models.Discussion, refs []string) []string {
+	if discussion == nil {
+		return nil
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		ref = strings.Trim(strings.TrimSpace(ref), "[]")
+		if ref == "" {
+			continue
+		}
+		for i, idea := range discussion.Ideas {
+			if !strings.EqualFold(discussion.IdeaHandle(i), ref) && !ideaMatchesRef(idea, ref) {
+				continue
+			}
+			if !seen[idea.ID] {
+				seen[idea.ID] = true
+				ids = append(ids, idea.ID)
+			}
+			break
+		}
+	}
+	return ids
+}
+
+// ideaMatchesRef reports whether ref identifies the idea or one of its merged duplicates
+func ideaMatchesRef(idea models.Idea, ref string) bool {
+	if idea.ID == ref || idea.ShortID() == ref || strings.EqualFold(idea.Title, ref) {
+		return true
+	}
+	for _, m := range idea.MergedFrom {
+		if m.ID == ref {
+			return true
+		}
+	}
+	return false
+}
@@ -1,9 +1,11 @@
 package agents
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/yourusername/ai-agent-team/internal/llm"
 	"github.com/yourusername/ai-agent-team/internal/models"
+	"strings"
 )
 
 // ImplementerAgent focuses on practical implementation
@@ -39,7 +41,32 @@ When responding:
 - Highlight potential blockers
 - Be realistic about timelines and effort
 
-Ground visionary ideas in practical execution plans.`
+Ground visionary ideas in practical execution plans.
+
+After your explanation, give a structured plan for each idea you planned as JSON:
+{
+  "plans": [
+    {
+      "idea_id": "handle of the idea, e.g. I2",
+      "summary": "One-sentence overview of the approach",
+      "phases": [
+        {
+          "id": "P1",
+          "name": "MVP",
+          "milestone": "What is true when the phase is done",
+          "duration_weeks": 4,
+          "depends_on": [],
+          "tasks": [
+            {"id": "T1", "title": "Task title", "description": "What the task involves", "effort_days": 5, "role": "backend engineer", "depends_on": []}
+          ]
+        }
+      ],
+      "roles": ["backend engineer", "designer"],
+      "success_metrics": [{"name": "Weekly active users", "target": "500 within 3 months"}]
+    }
+  ]
+}
+Phase and task IDs must be unique within a plan, and "depends_on" may only list IDs defined in the same plan.`
 
 	return &ImplementerAgent{
 		BaseAgent: &BaseAgent{
@@ -60,7 +87,8 @@ func (a *ImplementerAgent) Process(context *models.Discussion, input string) (*m
 
 Task: %s
 
-Focus on practical implementation. How would this actually be built or executed?`,
+Focus on practical implementation. How would this actually be built or executed?
+End with the JSON plans, referencing ideas by their handle.`,
 		discussionContext, input)
 
 	response, err := a.QueryStream(query)
@@ -71,5 +99,42 @@ Focus on practical implementation. How would this actually be built or executed?
 	return &models.AgentResponse{
 		AgentRole: a.Role,
 		Content:   response,
+		Plans:     a.extractPlans(response, context),
 	}, nil
 }
+
+// extractPlans parses the structured plans from the response, attributing
+// each to the idea it references. Plans are not validated here.
+func (a *ImplementerAgent) extractPlans(response string, discussion *models.Discussion) []models.IdeaPlan {
+	startIdx := strings.Index(response, "{")
+	endIdx := strings.LastIndex(response, "}")
+	if startIdx == -1 || endIdx == -1 || discussion == nil {
+		return nil
+	}
+
+	var parsed struct {
+		Plans []struct {
+			IdeaID string `json:"idea_id"`
+			models.ImplementationPlan
+		} `json:"plans"`
+	}
+	if err := json.Unmarshal([]byte(response[startIdx:endIdx+1]), &parsed); err != nil {
+		return nil
+	}
+
+	var plans []models.IdeaPlan
+	for _, p := range parsed.Plans {
+		i := findIdea(discussion, p.IdeaID, "", nil)
+		if i < 0 {
+			// A focused discussion (deep dive or planning) only shows one idea
+			if len(discussion.Ideas) != 1 {
+				continue
+			}
+			i = 0
+		}
+		plan := p.ImplementationPlan
+		plan.CreatedBy = string(a.Role)
+		plans = append(plans, models.IdeaPlan{IdeaID: discussion.Ideas[i].ID, Plan: &plan})
+	}
+	return plans
+}
@@ -6,16 +6,49 @@ import (
 	"github.com/yourusername/ai-agent-team/internal/llm"
 	"github.com/yourusername/ai-agent-team/internal/models"
 	"strings"
+	"unicode"
 )
 
 // ModeratorAgent validates and evaluates ideas
 type ModeratorAgent struct {
 	*BaseAgent
+	persona string // judging lens of a panel judge
 }
 
-// NewModeratorAgent creates a new moderator agent
+// NewModeratorAgent creates a new moderator agent scoring on the default
+// criteria; SetCriteria changes them
 func NewModeratorAgent(client llm.Client) *ModeratorAgent {
-	systemPrompt := `You are the Moderator/Facilitator Agent, responsible for ensuring idea quality and validity.
+	return &ModeratorAgent{
+		BaseAgent: &BaseAgent{
+			Role:         models.RoleModerator,
+			Name:         "Moderator/Facilitator",
+			SystemPrompt: moderatorPrompt(models.DefaultCriteria(), ""),
+			Client:       client,
+			Temperature:  0.5, // Lower temperature for analytical thinking
+		},
+	}
+}
+
+// NewModeratorJudge creates a moderator that sits on a judging panel with an
+// additional evaluation lens
+func NewModeratorJudge(client llm.Client, name, persona string) *ModeratorAgent {
+	a := NewModeratorAgent(client)
+	a.Name = fmt.Sprintf("Judge (%s)", name)
+	a.persona = persona
+	a.SystemPrompt = moderatorPrompt(models.DefaultCriteria(), persona)
+	return a
+}
+
+// SetCriteria rebuilds the system prompt around the criteria ideas are
+// scored on. Implements CriteriaScorer.
+func (a *ModeratorAgent) SetCriteria(criteria []models.Criterion) {
+	a.SystemPrompt = moderatorPrompt(criteria, a.persona)
+}
+
+// moderatorPrompt is the moderator's system prompt for the given criteria
+// and, for a panel judge, judging lens
+func moderatorPrompt(criteria []models.Criterion, persona string) string {
+	prompt := `You are the Moderator/Facilitator Agent, responsible for ensuring idea quality and validity.
 
 Your responsibilities:
 - Critically evaluate all proposed ideas
@@ -27,17 +60,14 @@ Your responsibilities:
 - Challenge assumptions and ask probing questions
 
 Evaluation criteria:
-- Feasibility: Can this be realistically implemented?
-- Innovation: Is this creative and differentiated?
-- Impact: What value does this provide?
-- Clarity: Is the idea well-defined and understandable?
-- Completeness: Is the idea fully thought through?
-
+` + formatCriteria(criteria) + `
 When evaluating ideas, structure your response as JSON:
 {
   "evaluations": [
     {
-      "idea_id": "id of the idea",
+      "idea_id": "handle of the idea, e.g. I1",
+      "title": "title of the idea",
+      "criteria_scores": {"<criterion name>": 8},
       "score": 8.5,
       "pros": ["strength 1", "strength 2"],
       "cons": ["weakness 1", "weakness 2"],
@@ -49,15 +79,10 @@ When evaluating ideas, structure your response as JSON:
 
 Be thorough, fair, and constructive. Your goal is to ensure only high-quality ideas move forward.`
 
-	return &ModeratorAgent{
-		BaseAgent: &BaseAgent{
-			Role:         models.RoleModerator,
-			Name:         "Moderator/Facilitator",
-			SystemPrompt: systemPrompt,
-			Client:       client,
-			Temperature:  0.5, // Lower temperature for analytical thinking
-		},
+	if persona != "" {
+		prompt += "\n\nYour judging lens: " + persona
 	}
+	return prompt
 }
 
 // Process evaluates ideas
@@ -68,8 +93,11 @@ func (a *ModeratorAgent) Process(context *models.Discussion, input string) (*mod
 
 Task: %s
 
-Evaluate the ideas presented. Provide scores, identify pros and cons, and give detailed feedback. Return your response as JSON following the specified format.`,
-		discussionContext, input)
+Evaluate the ideas presented, referencing each by its handle in "idea_id". Score every idea from 0-10 on each of these criteria, using the exact criterion names as keys in "criteria_scores":
+%s
+Weigh each idea's recorded risks: unmitigated high-severity risks should lower its score.
+Also identify pros and cons and give detailed feedback. Return your response as JSON following the specified format.`,
+		discussionContext, input, formatCriteria(discussionCriteria(context)))
 
 	response, err := a.QueryStream(query)
 	if err != nil {
@@ -98,11 +126,13 @@ func (a *ModeratorAgent) updateIdeasWithEvaluation(response string, discussion *
 
 	var parsed struct {
 		Evaluations []struct {
-			IdeaID   string   `json:"idea_id"`
-			Score    float64  `json:"score"`
-			Pros     []string `json:"pros"`
-			Cons     []string `json:"cons"`
-			Feedback string   `json:"feedback"`
+			IdeaID         string             `json:"idea_id"`
+			Title          string             `json:"title"`
+			CriteriaScores map[string]float64 `json:"criteria_scores"`
+			Score          float64            `json:"score"`
+			Pros           []string           `json:"pros"`
+			Cons           []string           `json:"cons"`
+			Feedback       string             `json:"feedback"`
 		} `json:"evaluations"`
 	}
 
@@ -110,16 +140,196 @@ func (a *ModeratorAgent) updateIdeasWithEvaluation(response string, discussion *
 		return
 	}
 
-	// Update ideas in the discussion
-	for i := range discussion.Ideas {
-		for _, eval := range parsed.Evaluations {
-			if discussion.Ideas[i].ID == eval.IdeaID ||
-				discussion.Ideas[i].Title == eval.IdeaID { // Allow matching by title too
-				discussion.Ideas[i].Score = eval.Score
-				discussion.Ideas[i].Pros = eval.Pros
-				discussion.Ideas[i].Cons = eval.Cons
-				discussion.Ideas[i].Validated = true
+	// Update ideas in the discussion; each idea takes at most one evaluation
+	criteria := discussionCriteria(discussion)
+	scored := make(map[int]bool)
+	for _, eval := range parsed.Evaluations {
+		i := findIdea(discussion, eval.IdeaID, eval.Title, scored)
+		if i < 0 {
+			if a.Notify != nil {
+				a.Notify(fmt.Sprintf("    ⚠️  %s: evaluation for %q matched no idea", a.Name, strings.TrimSpace(eval.IdeaID+" "+eval.Title)))
+			}
+			continue
+		}
+		scored[i] = true
+		discussion.Ideas[i].Score = eval.Score
+		discussion.Ideas[i].CriterionScores = matchCriteria(eval.CriteriaScores, criteria)
+		// The overall score is computed from the weights, not taken from the model
+		if weighted, ok := models.WeightedScore(discussion.Ideas[i].CriterionScores, criteria); ok {
+			discussion.Ideas[i].Score = weighted
+		}
+		discussion.Ideas[i].Pros = eval.Pros
+		discussion.Ideas[i].Cons = eval.Cons
+		discussion.Ideas[i].Validated = true
+	}
+}
+
+// findIdea finds the index of the idea an agent referenced, skipping ideas in
+// taken. It tries the handle, then the ID, then an exact title, then the
+// closest fuzzy title match; -1 means no match.
+func findIdea(discussion *models.Discussion, ref, title string, taken map[int]bool) int {
+	ref = strings.Trim(strings.TrimSpace(ref), "[]")
+	for i, idea := range discussion.Ideas {
+		if !taken[i] && ref != "" && (strings.EqualFold(discussion.IdeaHandle(i), ref) || ideaMatchesRef(idea, ref)) {
+			return i
+		}
+	}
+	for _, t := range []string{title, ref} {
+		for i, idea := range discussion.Ideas {
+			if !taken[i] && t != "" && strings.EqualFold(strings.TrimSpace(idea.Title), strings.TrimSpace(t)) {
+				return i
+			}
+		}
+	}
+
+	// Fuzzy fallback for paraphrased titles
+	best, bestSim := -1, minTitleSimilarity
+	for _, t := range []string{title, ref} {
+		words := titleWords(t)
+		for i, idea := range discussion.Ideas {
+			if taken[i] {
+				continue
+			}
+			if sim := wordOverlap(words, titleWords(idea.Title)); sim >= bestSim {
+				best, bestSim = i, sim
+			}
+		}
+	}
+	return best
+}
+
+// minTitleSimilarity is the word overlap needed to match a paraphrased title
+const minTitleSimilarity = 0.6
+
+// titleStopwords are filler words ignored when comparing titles
+var titleStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "app": true, "platform": true,
+}
+
+// titleWords returns the folded content words of a title. Words are cut to
+// five letters so "planner" and "planning" compare equal.
+func titleWords(title string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) < 3 || titleStopwords[w] {
+			continue
+		}
+		if len(w) > 5 {
+			w = w[:5]
+		}
+		words[w] = true
+	}
+	return words
+}
+
+// wordOverlap returns the share of the smaller word set found in the other,
+// requiring at least two shared words when both titles have two or more
+func wordOverlap(a, b map[string]bool) float64 {
+	smaller := min(len(a), len(b))
+	if smaller == 0 {
+		return 0
+	}
+	inter := 0
+	for w := range a {
+		if b[w] {
+			inter++
+		}
+	}
+	if inter < min(2, smaller) {
+		return 0
+	}
+	return float64(inter) / float64(smaller)
+}
+
+// CompareIdeas judges two ideas head-to-head and returns the winner ("A" or "B")
+// with a short reason. It is used by the pairwise tournament ranking phase.
+func (a *ModeratorAgent) CompareIdeas(discussion *models.Discussion, ideaA, ideaB *models.Idea) (string, string, error) {
+	topic := ""
+	if discussion != nil {
+		topic = discussion.Topic
+	}
+
+	query := fmt.Sprintf(`Topic: %s
+
+Compare these two ideas head-to-head. Ignore any previous scores and judge them on these criteria:
+%s
+Idea A: %s
+%s
+
+Idea B: %s
+%s
+
+Which idea is stronger overall? You must pick one — no ties.
+Respond with ONLY a JSON object:
+{"winner": "A" or "B", "reason": "One or two sentences explaining the decision"}`,
+		topic, formatCriteria(discussionCriteria(discussion)), ideaA.Title, ideaA.Description, ideaB.Title, ideaB.Description)
+
+	response, err := a.Query(query)
+	if err != nil {
+		return "", "", fmt.Errorf("moderator comparison failed: %w", err)
+	}
+
+	startIdx := strings.Index(response, "{")
+	endIdx := strings.LastIndex(response, "}")
+	if startIdx == -1 || endIdx == -1 {
+		return "", "", fmt.Errorf("no JSON verdict in comparison response")
+	}
+
+	var verdict struct {
+		Winner string `json:"winner"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(response[startIdx:endIdx+1]), &verdict); err != nil {
+		return "", "", fmt.Errorf("failed to parse comparison verdict: %w", err)
+	}
+
+	winner := strings.ToUpper(strings.TrimSpace(verdict.Winner))
+	if winner != "A" && winner != "B" {
+		return "", "", fmt.Errorf("invalid comparison winner %q", verdict.Winner)
+	}
+	return winner, verdict.Reason, nil
+}
+
+// discussionCriteria returns the discussion's scoring criteria, or the defaults
+func discussionCriteria(discussion *models.Discussion) []models.Criterion {
+	if discussion == nil || len(discussion.Criteria) == 0 {
+		return models.DefaultCriteria()
+	}
+	return discussion.Criteria
+}
+
+// formatCriteria lists criteria with their descriptions and weights for a prompt
+func formatCriteria(criteria []models.Criterion) string {
+	var b strings.Builder
+	for _, c := range criteria {
+		fmt.Fprintf(&b, "- %s (weight %.1f)", c.Name, c.Weight)
+		if c.Description != "" {
+			b.WriteString(": " + c.Description)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// matchCriteria maps the model's criterion keys onto the canonical criterion
+// names, ignoring case and dropping unknown criteria
+func matchCriteria(raw map[string]float64, criteria []models.Criterion) map[string]float64 {
+	if len(raw) == 0 {
+		return nil
+	}
+	scores := make(map[string]float64)
+	for key, score := range raw {
+		for _, c := range criteria {
+			if strings.EqualFold(strings.TrimSpace(key), c.Name) {
+				scores[c.Name] = score
+				break
 			}
 		}
 	}
+	if len(scores) == 0 {
+		return nil
+	}
+	return scores
 }
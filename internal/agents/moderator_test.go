@@ -0,0 +1,67 @@
+package agents
+
+import (
+	"testing"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// TestFindIdea checks references resolve by handle, ID and exact title, that
+// paraphrased titles fall back to the closest fuzzy match at or above
+// minTitleSimilarity, and that taken ideas are never matched again.
+func TestFindIdea(t *testing.T) {
+	discussion := &models.Discussion{Ideas: []models.Idea{
+		{ID: "idea-1", Title: "Neighbourhood food sharing network"},
+		{ID: "idea-2", Title: "Neighbourhood food sharing events"},
+		{ID: "idea-3", Title: "Surplus produce marketplace"},
+	}}
+
+	tests := []struct {
+		name  string
+		ref   string
+		title string
+		taken map[int]bool
+		want  int
+	}{
+		{"handle", "[I2]", "", nil, 1},
+		{"id", "idea-3", "", nil, 2},
+		{"exact title", "", "surplus produce marketplace", nil, 2},
+		{"handle beats title", "I1", "Surplus produce marketplace", nil, 0},
+		{"near-miss spelling", "", "Neighborhood food-sharing network", nil, 0},
+		{"reworded similar title", "", "Food sharing events for neighbours", nil, 1},
+		{"paraphrased ref", "Produce swap marketplace network", "", nil, 2},
+		{"taken exact match", "", "Neighbourhood food sharing network", map[int]bool{0: true}, 1},
+		{"taken handle", "I3", "", map[int]bool{2: true}, -1},
+		{"below threshold", "", "Food network for garden tool library", nil, -1}, // 2 of 4 words
+		{"one shared word", "", "Food delivery by drone", nil, -1},
+		{"nothing to match", "", "", nil, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findIdea(discussion, tt.ref, tt.title, tt.taken); got != tt.want {
+				t.Errorf("findIdea(%q, %q) = %d, want %d", tt.ref, tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWordOverlap checks the overlap is measured against the smaller title
+// and that a single shared word is not enough between multi-word titles.
+func TestWordOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"Meal planner", "Meal planning", 1},
+		{"The meal planning app", "Meal planning", 1},
+		{"Community fridges", "Community gardens", 0},
+		{"Fridges", "Community fridges", 1},
+		{"Surplus produce marketplace", "Produce swap marketplace network", 2.0 / 3},
+		{"", "Community fridges", 0},
+	}
+	for _, tt := range tests {
+		if got := wordOverlap(titleWords(tt.a), titleWords(tt.b)); got != tt.want {
+			t.Errorf("wordOverlap(%q, %q) = %.3f, want %.3f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package agents
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/ai-agent-team/internal/llm"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// Hooks exposes the settings the orchestrator configures on every agent:
+// streaming and notify callbacks, the model name, the Firecrawl key and
+// tools. Any agent embedding *BaseAgent implements it.
+type Hooks interface {
+	SetOnChunk(fn func(string))
+	SetNotify(fn func(string))
+	SetModel(model string)
+	SetFirecrawlKey(key string)
+	RegisterTool(def llm.ToolDefinition, executor func(args string) (string, error))
+}
+
+// PhaseParticipant is implemented by agents that join discussion phases
+// outside the built-in sequence. PhasePrompt returns the task prompt for a
+// phase ("exploration" or "deep_dive") the agent takes part in.
+type PhaseParticipant interface {
+	PhasePrompt(phase string) (string, bool)
+}
+
+// ReportGenerator is implemented by agents that render the final idea sheet.
+type ReportGenerator interface {
+	GenerateIdeaSheet(discussion *models.Discussion) (string, error)
+}
+
+// PairwiseJudge is implemented by agents that can judge two ideas
+// head-to-head, returning "A" or "B" and a reason.
+type PairwiseJudge interface {
+	CompareIdeas(discussion *models.Discussion, ideaA, ideaB *models.Idea) (string, string, error)
+}
+
+// TurnDirector is implemented by agents that can direct a leader-led
+// exploration round, choosing the next speaker from the candidates or
+// ending the round.
+type TurnDirector interface {
+	NextTurn(discussion *models.Discussion, candidates []models.TurnCandidate, turnsLeft int) (*models.TurnDecision, error)
+}
+
+// CriteriaScorer is implemented by agents that score ideas and need the
+// discussion's scoring criteria in their system prompt.
+type CriteriaScorer interface {
+	SetCriteria(criteria []models.Criterion)
+}
+
+// Factory creates an agent backed by the given client.
+type Factory func(client llm.Client) Agent
+
+var registry = struct {
+	sync.RWMutex
+	factories map[models.AgentRole]Factory
+}{factories: make(map[models.AgentRole]Factory)}
+
+func init() {
+	Register(models.RoleTeamLeader, func(c llm.Client) Agent { return NewTeamLeaderAgent(c) })
+	Register(models.RoleIdeation, func(c llm.Client) Agent { return NewIdeationAgent(c) })
+	Register(models.RoleModerator, func(c llm.Client) Agent { return NewModeratorAgent(c) })
+	Register(models.RoleResearcher, func(c llm.Client) Agent { return NewResearcherAgent(c) })
+	Register(models.RoleCritic, func(c llm.Client) Agent { return NewCriticAgent(c) })
+	Register(models.RoleImplementer, func(c llm.Client) Agent { return NewImplementerAgent(c) })
+	Register(models.RoleUICreator, func(c llm.Client) Agent { return NewUICreatorAgent(c) })
+}
+
+// Register adds a factory for a role, replacing any existing one. Packages in
+// this module call it (typically from init) to add agents the orchestrator
+// can create; include the role in TeamConfig.ExtraRoles to add it to a team.
+// The package is internal, so code outside the module cannot register Go
+// agents; it can add prompt-only roles with LoadRoleDefinitions instead.
+func Register(role models.AgentRole, factory Factory) {
+	if factory == nil {
+		panic(fmt.Sprintf("agents: nil factory for role %q", role))
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	registry.factories[role] = factory
+}
+
+// Lookup returns the registered factory for a role.
+func Lookup(role models.AgentRole) (Factory, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	f, ok := registry.factories[role]
+	return f, ok
+}
+
+// RegisteredRoles returns all roles with a registered factory, sorted.
+func RegisteredRoles() []models.AgentRole {
+	registry.RLock()
+	defer registry.RUnlock()
+	roles := make([]models.AgentRole, 0, len(registry.factories))
+	for role := range registry.factories {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i] < roles[j] })
+	return roles
+}
@@ -0,0 +1,218 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// DefaultRolesDir is where custom role definitions are loaded from when
+// AGENT_ROLES_DIR is not set.
+const DefaultRolesDir = "agents/custom"
+
+// knownTools are the tool names a custom role may request
+var knownTools = map[string]bool{"web_search": true}
+
+var (
+	roleIDPattern    = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	numberPattern    = regexp.MustCompile(`[0-9]*\.?[0-9]+`)
+	phaseLinePattern = regexp.MustCompile("^[-*]\\s+\\**`?([a-z_]+)`?\\**\\s*(?::|—|–|-)\\s*(.+)$")
+)
+
+// RolesDir returns the custom role directory: AGENT_ROLES_DIR if set,
+// otherwise DefaultRolesDir.
+func RolesDir() string {
+	if dir := os.Getenv("AGENT_ROLES_DIR"); dir != "" {
+		return dir
+	}
+	return DefaultRolesDir
+}
+
+// LoadRoleDefinitions parses every *.md file in dir as a custom role. A
+// missing directory is not an error and yields no roles.
+func LoadRoleDefinitions(dir string) ([]models.CustomRole, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("listing role definitions: %w", err)
+	}
+	sort.Strings(files)
+
+	var roles []models.CustomRole
+	seen := make(map[models.AgentRole]string)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading role definition %s: %w", file, err)
+		}
+		role, err := ParseRoleDefinition(data, file)
+		if err != nil {
+			return nil, err
+		}
+		if prev, dup := seen[role.Role]; dup {
+			return nil, fmt.Errorf("role %q defined in both %s and %s", role.Role, prev, file)
+		}
+		seen[role.Role] = file
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// ParseRoleDefinition parses a markdown role definition in the shape of the
+// agents/agents/*.md docs: a "# Name" title, an Identity table with Role ID,
+// Name and One-liner, and "## Temperature", "## Tools", "## System Prompt",
+// "## Output Schema" and "## Phases" sections.
+func ParseRoleDefinition(data []byte, source string) (models.CustomRole, error) {
+	role := models.CustomRole{Temperature: 0.7, Phases: make(map[string]string), Source: source}
+	fail := func(format string, args ...interface{}) (models.CustomRole, error) {
+		return models.CustomRole{}, fmt.Errorf("role definition %s: %s", source, fmt.Sprintf(format, args...))
+	}
+
+	sections := splitSections(string(data))
+	role.Name = sections["#"]
+
+	for _, line := range strings.Split(sections["identity"], "\n") {
+		cells := strings.Split(strings.Trim(strings.TrimSpace(line), "|"), "|")
+		if len(cells) < 2 {
+			continue
+		}
+		key := strings.ToLower(cleanCell(cells[0]))
+		value := cleanCell(cells[1])
+		switch key {
+		case "role id":
+			role.Role = models.AgentRole(value)
+		case "name":
+			role.Name = value
+		case "one-liner", "persona":
+			role.Persona = value
+		}
+	}
+
+	if temp := numberPattern.FindString(sections["temperature"]); temp != "" {
+		t, err := strconv.ParseFloat(temp, 64)
+		if err != nil || t < 0 || t > 2 {
+			return fail("invalid temperature %q", temp)
+		}
+		role.Temperature = t
+	}
+
+	for _, line := range strings.Split(sections["tools"], "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "*") {
+			continue
+		}
+		tool := strings.Fields(cleanCell(strings.TrimLeft(line, "-* ")))
+		if len(tool) == 0 {
+			continue
+		}
+		if !knownTools[tool[0]] {
+			return fail("unknown tool %q", tool[0])
+		}
+		role.Tools = append(role.Tools, tool[0])
+	}
+
+	role.SystemPrompt = fencedBlock(sections["system prompt"])
+	role.OutputSchema = fencedBlock(sections["output schema"])
+
+	for _, line := range strings.Split(sections["phases"], "\n") {
+		m := phaseLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		switch m[1] {
+		case models.CustomPhaseExploration, models.CustomPhaseDeepDive:
+			role.Phases[m[1]] = strings.TrimSpace(m[2])
+		default:
+			return fail("unsupported phase %q (use %s or %s)", m[1], models.CustomPhaseExploration, models.CustomPhaseDeepDive)
+		}
+	}
+
+	switch {
+	case !roleIDPattern.MatchString(string(role.Role)):
+		return fail("missing or invalid Role ID %q (lowercase letters, digits and underscores)", role.Role)
+	case isBuiltinRole(role.Role):
+		return fail("role ID %q is a built-in role", role.Role)
+	case role.SystemPrompt == "":
+		return fail("missing System Prompt code block")
+	case len(role.Phases) == 0:
+		return fail("no Phases listed")
+	}
+	if role.Name == "" {
+		role.Name = string(role.Role)
+	}
+	return role, nil
+}
+
+// isBuiltinRole reports whether role is one of the Go-defined roles
+func isBuiltinRole(role models.AgentRole) bool {
+	switch role {
+	case models.RoleTeamLeader, models.RoleIdeation, models.RoleModerator, models.RoleUICreator,
+		models.RoleResearcher, models.RoleCritic, models.RoleImplementer:
+		return true
+	}
+	return false
+}
+
+// splitSections maps lower-cased "## " headings to their bodies; the "# "
+// title is stored under "#".
+func splitSections(md string) map[string]string {
+	sections := make(map[string]string)
+	current := ""
+	var body []string
+	flush := func() {
+		if current != "" {
+			sections[current] = strings.Join(body, "\n")
+		}
+		body = nil
+	}
+
+	inFence := false
+	for _, line := range strings.Split(md, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		switch {
+		case !inFence && strings.HasPrefix(line, "## "):
+			flush()
+			current = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(line, "## ")))
+			// "## Output Schema (optional)" → "output schema"
+			if i := strings.Index(current, "("); i > 0 {
+				current = strings.TrimSpace(current[:i])
+			}
+		case !inFence && strings.HasPrefix(line, "# ") && sections["#"] == "":
+			sections["#"] = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		default:
+			body = append(body, line)
+		}
+	}
+	flush()
+	return sections
+}
+
+// fencedBlock returns the contents of the first ``` code block in s
+func fencedBlock(s string) string {
+	start := strings.Index(s, "```")
+	if start == -1 {
+		return ""
+	}
+	rest := s[start+3:]
+	if nl := strings.Index(rest, "\n"); nl >= 0 {
+		rest = rest[nl+1:] // skip the language tag line
+	}
+	end := strings.Index(rest, "```")
+	if end == -1 {
+		return ""
+	}
+	return strings.TrimSpace(rest[:end])
+}
+
+// cleanCell strips markdown emphasis and code markers from a table cell
+func cleanCell(s string) string {
+	return strings.TrimSpace(strings.NewReplacer("**", "", "`", "").Replace(s))
+}
@@ -0,0 +1,107 @@
+package agents
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// roleMarkdown builds a role definition, replacing the identity table rows,
+// temperature and phases when given
+func roleMarkdown(identity, temperature, phases string) string {
+	return fmt.Sprintf("# Data Steward\n\n## Identity\n\n| Field | Value |\n|-------|-------|\n%s\n\n## Temperature\n\n%s\n\n## System Prompt\n\n```\nYou look after the data.\n```\n\n## Phases\n\n%s\n", identity, temperature, phases)
+}
+
+// TestParseRoleDefinitionHeader checks the title and Identity table: the
+// table's name wins over the title, which wins over the role ID, and
+// markdown emphasis and code markers are stripped from cells.
+func TestParseRoleDefinitionHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		identity string
+		wantName string
+	}{
+		{"table name", "| **Role ID** | `data_steward` |\n| **Name** | Steward of Data |\n| **One-liner** | Guards data quality |", "Steward of Data"},
+		{"title fallback", "| Role ID | data_steward |\n| Persona | Guards data quality |", "Data Steward"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := ParseRoleDefinition([]byte(roleMarkdown(tt.identity, "**0.4** — careful", "- `exploration` — Check the data behind each idea")), "steward.md")
+			if err != nil {
+				t.Fatalf("ParseRoleDefinition: %v", err)
+			}
+			if role.Role != "data_steward" || role.Name != tt.wantName || role.Persona != "Guards data quality" {
+				t.Errorf("identity = %q, %q, %q; want data_steward, %q, Guards data quality", role.Role, role.Name, role.Persona, tt.wantName)
+			}
+			if role.Temperature != 0.4 || role.SystemPrompt != "You look after the data." || role.Source != "steward.md" {
+				t.Errorf("temperature %v, prompt %q, source %q", role.Temperature, role.SystemPrompt, role.Source)
+			}
+			if got := role.Phases[models.CustomPhaseExploration]; got != "Check the data behind each idea" {
+				t.Errorf("exploration prompt = %q", got)
+			}
+		})
+	}
+}
+
+// TestParseRoleDefinitionErrors checks invalid role IDs, unknown phases and
+// other malformed definitions are rejected with the reason.
+func TestParseRoleDefinitionErrors(t *testing.T) {
+	const phase = "- exploration: Check the data"
+	tests := []struct {
+		name        string
+		identity    string
+		temperature string
+		phases      string
+		wantErr     string
+	}{
+		{"missing role ID", "| Name | Steward |", "0.5", phase, "missing or invalid Role ID"},
+		{"uppercase role ID", "| Role ID | Data_Steward |", "0.5", phase, "invalid Role ID"},
+		{"role ID with a hyphen", "| Role ID | data-steward |", "0.5", phase, "invalid Role ID"},
+		{"role ID starting with a digit", "| Role ID | 2nd_opinion |", "0.5", phase, "invalid Role ID"},
+		{"built-in role", "| Role ID | critic |", "0.5", phase, "built-in role"},
+		{"unknown phase", "| Role ID | data_steward |", "0.5", "- kickoff: Say hello", `unsupported phase "kickoff"`},
+		{"no phases", "| Role ID | data_steward |", "0.5", "", "no Phases listed"},
+		{"temperature out of range", "| Role ID | data_steward |", "2.5", phase, "invalid temperature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRoleDefinition([]byte(roleMarkdown(tt.identity, tt.temperature, tt.phases)), "steward.md")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), "steward.md") {
+				t.Errorf("error = %v, want one naming steward.md and containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	noPrompt := strings.Replace(roleMarkdown("| Role ID | data_steward |", "0.5", phase), "```\nYou look after the data.\n```", "You look after the data.", 1)
+	if _, err := ParseRoleDefinition([]byte(noPrompt), "steward.md"); err == nil || !strings.Contains(err.Error(), "System Prompt") {
+		t.Errorf("error without a prompt code block = %v", err)
+	}
+	withTool := roleMarkdown("| Role ID | data_steward |", "0.5", phase) + "\n## Tools\n\n- `shell` — run commands\n"
+	if _, err := ParseRoleDefinition([]byte(withTool), "steward.md"); err == nil || !strings.Contains(err.Error(), `unknown tool "shell"`) {
+		t.Errorf("error for an unknown tool = %v", err)
+	}
+}
+
+// TestLoadShippedRoleExamples parses the example roles shipped in
+// agents/examples/roles, so the documented format stays loadable.
+func TestLoadShippedRoleExamples(t *testing.T) {
+	roles, err := LoadRoleDefinitions("../../agents/examples/roles")
+	if err != nil {
+		t.Fatalf("LoadRoleDefinitions: %v", err)
+	}
+	if len(roles) == 0 {
+		t.Fatal("no example roles found")
+	}
+	for _, role := range roles {
+		if role.Name == "" || role.SystemPrompt == "" || len(role.Phases) == 0 {
+			t.Errorf("%s: name %q, %d-byte prompt, phases %v", role.Source, role.Name, len(role.SystemPrompt), role.Phases)
+		}
+		for phase, prompt := range role.Phases {
+			if prompt == "" {
+				t.Errorf("%s: empty %s prompt", role.Source, phase)
+			}
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package agents
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/yourusername/ai-agent-team/internal/llm"
 	"github.com/yourusername/ai-agent-team/internal/models"
+	"strings"
 )
 
 // TeamLeaderAgent manages the team and coordinates discussions
@@ -54,7 +56,7 @@ When responding:
 
 // Process handles input and generates a response
 func (a *TeamLeaderAgent) Process(context *models.Discussion, input string) (*models.AgentResponse, error) {
-	discussionContext := BuildContext(context)
+	discussionContext := BuildContext(context) + context.MemoryContext()
 
 	query := fmt.Sprintf(`%s
 
@@ -73,3 +75,45 @@ Provide your leadership input. What should the team focus on next? Who should co
 		Content:   response,
 	}, nil
 }
+
+// NextTurn picks who should speak next in a leader-directed exploration round,
+// or reports that the round is done
+func (a *TeamLeaderAgent) NextTurn(discussion *models.Discussion, candidates []models.TurnCandidate, turnsLeft int) (*models.TurnDecision, error) {
+	var roster strings.Builder
+	for _, c := range candidates {
+		fmt.Fprintf(&roster, "- %s (%s): default task \"%s\"\n", c.Role, c.Name, c.Prompt)
+	}
+
+	query := fmt.Sprintf(`%s
+
+You are directing this exploration round turn by turn; %d turn(s) remain.
+Team members who can speak next:
+%s
+Decide who should speak next to move the discussion forward. Use back-and-forth where it helps, e.g. let ideation answer the critic's objections and then have the critic check the revision. Answer "done" once the round has covered enough ground.
+Respond with ONLY a JSON object:
+{"next": "role from the list, or done", "prompt": "Specific task for that team member", "reason": "Why they should speak now"}`,
+		BuildContext(discussion), turnsLeft, roster.String())
+
+	response, err := a.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("team leader turn decision failed: %w", err)
+	}
+
+	startIdx := strings.Index(response, "{")
+	endIdx := strings.LastIndex(response, "}")
+	if startIdx == -1 || endIdx == -1 {
+		return nil, fmt.Errorf("no JSON decision in turn response")
+	}
+	var decision models.TurnDecision
+	if err := json.Unmarshal([]byte(response[startIdx:endIdx+1]), &decision); err != nil {
+		return nil, fmt.Errorf("failed to parse turn decision: %w", err)
+	}
+
+	next := models.AgentRole(strings.ToLower(strings.TrimSpace(string(decision.Role))))
+	if next == "done" || next == "" {
+		return &models.TurnDecision{Done: true, Reason: decision.Reason}, nil
+	}
+	decision.Role = next
+	decision.Prompt = strings.TrimSpace(decision.Prompt)
+	return &decision, nil
+}
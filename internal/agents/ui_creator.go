@@ -8,6 +8,10 @@ import (
 	"github.com/yourusername/ai-agent-team/internal/models"
 )
 
+// IdeaSheetMaxTokens is the response length the UI creator asks for when
+// writing the idea sheet; its model must be able to produce this much.
+const IdeaSheetMaxTokens = 16384
+
 // UICreatorAgent creates beautiful visualizations of ideas
 type UICreatorAgent struct {
 	*BaseAgent
@@ -53,15 +57,16 @@ REQUIRED REPORT STRUCTURE:
    - Could elements be combined with the winner?
 
 4. COMPARATIVE ANALYSIS
-   - Side-by-side comparison table of all top ideas
+   - Do NOT draw your own score comparison table; a data-driven table of the recorded scores is appended automatically
    - Decision criteria and weightings
    - Trade-offs considered
-   - Scoring breakdown
+   - Scoring breakdown (use the recorded criterion scores and weights from the context)
 
 5. DISCUSSION JOURNEY
    - How the discussion evolved across rounds
    - Key insights and turning points
    - How ideas were refined or eliminated
+   - The version history of the winning idea (use the "Evolution" lineage in the context)
    - Team dynamics and disagreements
    - What the team learned
 
@@ -122,9 +127,9 @@ CRITICAL REQUIREMENTS:
 1. Give the winning proposal a catchy MARKETING NICKNAME and a punchy 3-4 LETTER ACRONYM
 2. Include detailed analysis of 3-5 runner-up ideas (not just the winner)
 3. For EACH runner-up, explain specifically WHY it was eliminated, its pros/cons, and team concerns
-4. Include a comparative analysis table of all top ideas
-5. Show the discussion journey across all rounds with key turning points
-6. List open questions, risks, and assumptions that need validation
+4. Compare the top ideas qualitatively; quote only the scores given in the context (a per-criterion score table is appended automatically, so do not invent one). Include tournament ratings and the head-to-head win/loss matrix if a tournament ran
+5. Show the discussion journey across all rounds with key turning points, tracing how ideas evolved from their earlier versions
+6. List open questions, risks, and assumptions that need validation, and clearly flag any ideas the judges strongly disagreed on
 7. Provide actionable next steps with success metrics
 8. Make it 5-8 printed pages of content — this is a strategic decision document, not a summary slide
 
@@ -133,7 +138,7 @@ Return complete, self-contained HTML with embedded CSS.`,
 		discussionContext, detailedContext, input)
 
 	// Use generous token limit for comprehensive report generation
-	response, err := a.QueryWithTokens(query, 16384)
+	response, err := a.QueryWithTokens(query, IdeaSheetMaxTokens)
 	if err != nil {
 		return nil, fmt.Errorf("report generator query failed: %w", err)
 	}
@@ -157,7 +162,11 @@ func (a *UICreatorAgent) buildDetailedContext(discussion *models.Discussion) str
 
 	// Round information
 	if discussion.MaxRounds > 1 {
-		context += fmt.Sprintf("Discussion Rounds: %d rounds completed\n\n", discussion.Round)
+		context += fmt.Sprintf("Discussion Rounds: %d rounds completed\n", discussion.Round)
+		if discussion.StopReason == models.StopReasonConverged {
+			context += "The team converged early, so the discussion stopped before the round limit\n"
+		}
+		context += "\n"
 	}
 
 	// Messages by round/phase
@@ -170,15 +179,43 @@ func (a *UICreatorAgent) buildDetailedContext(discussion *models.Discussion) str
 
 	// All ideas with full details
 	context += fmt.Sprintf("Total Ideas Generated: %d\n\n", len(discussion.Ideas))
-	context += "Detailed Ideas:\n"
+	context += "Scoring criteria (overall score is the weighted mean):\n" + formatCriteria(discussionCriteria(discussion))
+	context += "\nDetailed Ideas:\n"
 	for i, idea := range discussion.Ideas {
 		context += fmt.Sprintf("\nIdea %d: %s\n", i+1, idea.Title)
 		context += fmt.Sprintf("  Description: %s\n", idea.Description)
 		context += fmt.Sprintf("  Category: %s\n", idea.Category)
 		context += fmt.Sprintf("  Created by: %s\n", idea.CreatedBy)
+		if idea.Round > 0 {
+			context += fmt.Sprintf("  Proposed in round: %d\n", idea.Round)
+		}
+		if len(idea.Revisions) > 0 {
+			var steps []string
+			for v, rev := range idea.Revisions {
+				steps = append(steps, fmt.Sprintf("v%d %q (round %d)", v+1, rev.Title, rev.Round))
+			}
+			steps = append(steps, fmt.Sprintf("v%d this idea", len(idea.Revisions)+1))
+			context += fmt.Sprintf("  Evolution: %s\n", strings.Join(steps, " → "))
+		}
+		for _, m := range idea.MergedFrom {
+			context += fmt.Sprintf("  Merged duplicate: %s (by %s, %s similarity %.2f)\n", m.Title, m.CreatedBy, m.Method, m.Similarity)
+		}
 
 		if idea.Validated {
 			context += fmt.Sprintf("  Score: %.1f/10\n", idea.Score)
+			if len(idea.CriterionScores) > 0 {
+				context += fmt.Sprintf("  Criterion scores: %s\n", formatCriterionScores(discussion, idea))
+			}
+			if len(idea.JudgeScores) > 1 {
+				var judges []string
+				for _, js := range idea.JudgeScores {
+					judges = append(judges, fmt.Sprintf("%s %.1f", js.Judge, js.Score))
+				}
+				context += fmt.Sprintf("  Judge scores: %s (variance %.2f)\n", strings.Join(judges, ", "), idea.ScoreVariance)
+			}
+			if idea.Disputed {
+				context += "  ⚠️ HIGH DISAGREEMENT between judges — flag this in the report\n"
+			}
 			if len(idea.Pros) > 0 {
 				context += "  Pros:\n"
 				for _, pro := range idea.Pros {
@@ -191,6 +228,68 @@ func (a *UICreatorAgent) buildDetailedContext(discussion *models.Discussion) str
 					context += fmt.Sprintf("    - %s\n", con)
 				}
 			}
+		} else if len(discussion.UnscoredIdeas) > 0 {
+			context += "  Not scored: the evaluation did not cover this idea — say so rather than showing a score\n"
+		}
+		if p := idea.Plan; p != nil {
+			context += fmt.Sprintf("  Implementation plan (%.0f weeks, %.0f person-days): %s\n", p.TotalWeeks(), p.TotalEffortDays(), p.Summary)
+			for _, ph := range p.Phases {
+				context += fmt.Sprintf("    - %s (weeks %.0f-%.0f): %s\n", ph.Name, ph.StartWeek, ph.StartWeek+ph.DurationWeeks, ph.Milestone)
+			}
+		}
+		if len(idea.Risks) > 0 {
+			context += "  Risk register:\n"
+			for _, r := range models.SortRisks(idea.Risks) {
+				context += "  " + formatRisk(r) + "\n"
+			}
+		}
+
+		if dd := idea.DeepDive; dd != nil {
+			context += "  Deep Dive:\n"
+			if dd.Evidence != "" {
+				context += fmt.Sprintf("    Evidence: %s\n", truncate(dd.Evidence, 400))
+			}
+			if dd.Risks != "" {
+				context += fmt.Sprintf("    Risks: %s\n", truncate(dd.Risks, 400))
+			}
+			if dd.Plan != "" {
+				context += fmt.Sprintf("    Plan: %s\n", truncate(dd.Plan, 400))
+			}
+			for role, note := range dd.Notes {
+				context += fmt.Sprintf("    %s: %s\n", role, truncate(note, 400))
+			}
+		}
+	}
+
+	if discussion.DeepDiveOrder == models.DeepDiveByActivity {
+		context += "\nDeep dives were chosen by how often ideas were revised and merged, because no idea was scored yet; say so where the deep dives are shown\n"
+	}
+
+	if discussion.Tournament != nil {
+		context += buildTournamentContext(discussion)
+	}
+
+	if len(discussion.RoundSummaries) > 0 {
+		context += "\nRound summaries from the team leader (use them for the discussion timeline):\n"
+		for _, rs := range discussion.RoundSummaries {
+			context += fmt.Sprintf("  Round %d: %d idea(s) added", rs.Round, len(rs.IdeasAdded))
+			if len(rs.IdeasDropped) > 0 {
+				context += fmt.Sprintf(", %d set aside", len(rs.IdeasDropped))
+			}
+			context += "\n"
+			for _, insight := range rs.KeyInsights {
+				context += fmt.Sprintf("    - %s\n", insight)
+			}
+			if rs.Focus != "" {
+				context += fmt.Sprintf("    Next focus: %s\n", rs.Focus)
+			}
+		}
+	}
+
+	if open := discussion.UnansweredQuestions(); len(open) > 0 {
+		context += "\nUnanswered questions between team members (list them in the open questions section):\n"
+		for _, q := range open {
+			context += fmt.Sprintf("  - %s asked %s: %s\n", q.From, q.To, q.Content)
 		}
 	}
 
@@ -198,11 +297,75 @@ func (a *UICreatorAgent) buildDetailedContext(discussion *models.Discussion) str
 	if discussion.FinalIdea != nil {
 		context += fmt.Sprintf("\nFinal Selected Idea: %s (Score: %.1f/10)\n",
 			discussion.FinalIdea.Title, discussion.FinalIdea.Score)
+		if discussion.Tournament != nil {
+			context += "The winner was chosen by tournament rating rather than absolute score\n"
+		}
+	}
+	if discussion.Summary != "" {
+		context += fmt.Sprintf("\nExecutive Summary (use it as written for the summary section):\n%s\n", discussion.Summary)
+	}
+
+	return context
+}
+
+// formatCriterionScores lists an idea's per-criterion scores in criteria order
+func formatCriterionScores(discussion *models.Discussion, idea models.Idea) string {
+	var parts []string
+	for _, c := range discussionCriteria(discussion) {
+		if s, ok := idea.CriterionScores[c.Name]; ok {
+			parts = append(parts, fmt.Sprintf("%s %.1f", c.Name, s))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// buildTournamentContext lists tournament ratings and the win/loss matrix
+func buildTournamentContext(discussion *models.Discussion) string {
+	t := discussion.Tournament
+	titles := make([]string, len(t.IdeaIDs))
+	context := fmt.Sprintf("\nPairwise Tournament (%s format, %d matches judged head-to-head):\n", t.Format, len(t.Matches))
+	for i, id := range t.IdeaIDs {
+		titles[i] = id
+		for _, idea := range discussion.Ideas {
+			if idea.ID == id {
+				titles[i] = idea.Title
+				context += fmt.Sprintf("  %s: rating %.0f, %d wins, %d losses\n", idea.Title, idea.Rating, idea.Wins, idea.Losses)
+				break
+			}
+		}
+	}
+
+	context += "  Win/loss matrix (row beat column):\n"
+	for i, row := range t.WinMatrix {
+		var cells []string
+		for j, wins := range row {
+			if i != j && wins+t.WinMatrix[j][i] > 0 {
+				cells = append(cells, fmt.Sprintf("vs %s %d-%d", truncate(titles[j], 30), wins, t.WinMatrix[j][i]))
+			}
+		}
+		if len(cells) > 0 {
+			context += fmt.Sprintf("    %s: %s\n", truncate(titles[i], 30), strings.Join(cells, "; "))
+		}
 	}
 
+	for _, m := range t.Matches {
+		if m.Reason != "" {
+			context += fmt.Sprintf("  Round %d verdict for %s: %s\n", m.Round, titleOf(titles, t.IdeaIDs, m.Winner), truncate(m.Reason, 200))
+		}
+	}
 	return context
 }
 
+// titleOf looks up the title for an idea ID in parallel ID/title slices
+func titleOf(titles, ids []string, id string) string {
+	for i, v := range ids {
+		if v == id {
+			return titles[i]
+		}
+	}
+	return id
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -227,7 +390,7 @@ Focus on:
 - Top %d ideas with detailed analysis including pros, cons, and team concerns
 - Specific reasons why each runner-up was eliminated
 - What circumstances might favor each alternative
-- A side-by-side comparative analysis table
+- A qualitative side-by-side comparison (the numeric score table is appended for you)
 - Open questions, risks, and assumptions to validate
 - Actionable next steps with success metrics
 
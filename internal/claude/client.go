@@ -135,7 +135,7 @@ func (c *Client) SendMessageStream(messages []Message, systemPrompt string, temp
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return "", &llm.APIError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var sb strings.Builder
@@ -199,7 +199,7 @@ func (c *Client) doRequest(req apiRequest) (string, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return "", &llm.APIError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var apiResp Response
@@ -207,7 +207,7 @@ func (c *Client) doRequest(req apiRequest) (string, error) {
 		return "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 	if len(apiResp.Content) == 0 {
-		return "", fmt.Errorf("no content in response")
+		return "", fmt.Errorf("%w: no content", llm.ErrMalformedResponse)
 	}
 	return apiResp.Content[0].Text, nil
 }
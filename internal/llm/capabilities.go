@@ -0,0 +1,53 @@
+package llm
+
+// Capabilities describes what a model can be used for.
+type Capabilities struct {
+	Chat            bool // serves chat completions
+	Tools           bool // supports tool calling
+	MaxOutputTokens int  // longest response the model can produce
+}
+
+// DefaultCapabilities is assumed for chat models missing from the table:
+// no tool calling and a short output limit, so role requirements stay conservative.
+var DefaultCapabilities = Capabilities{Chat: true, MaxOutputTokens: 4096}
+
+// capabilities maps model ID prefixes to capabilities; the longest matching prefix wins.
+var capabilities = map[string]Capabilities{
+	"claude-opus-4":     {Chat: true, Tools: true, MaxOutputTokens: 32000},
+	"claude-sonnet-4":   {Chat: true, Tools: true, MaxOutputTokens: 64000},
+	"claude-3-7-sonnet": {Chat: true, Tools: true, MaxOutputTokens: 64000},
+	"claude-haiku-3-5":  {Chat: true, Tools: true, MaxOutputTokens: 8192},
+	"claude-3-5":        {Chat: true, Tools: true, MaxOutputTokens: 8192},
+	"gpt-4o":            {Chat: true, Tools: true, MaxOutputTokens: 16384},
+	"gpt-4o-mini":       {Chat: true, Tools: true, MaxOutputTokens: 16384},
+	"gpt-4.1":           {Chat: true, Tools: true, MaxOutputTokens: 32768},
+	"gpt-4-turbo":       {Chat: true, Tools: true, MaxOutputTokens: 4096},
+	"gpt-3.5-turbo":     {Chat: true, Tools: true, MaxOutputTokens: 4096},
+	"o1-mini":           {Chat: true, MaxOutputTokens: 65536},
+	"o1-preview":        {Chat: true, MaxOutputTokens: 32768},
+	"o3-mini":           {Chat: true, Tools: true, MaxOutputTokens: 100000},
+
+	// Listed by OpenAI-compatible /models endpoints but not usable for chat
+	"text-embedding":         {},
+	"whisper":                {},
+	"tts":                    {},
+	"dall-e":                 {},
+	"gpt-image":              {},
+	"davinci":                {},
+	"babbage":                {},
+	"omni-moderation":        {},
+	"text-moderation":        {},
+	"gpt-4o-audio":           {},
+	"gpt-4o-realtime":        {},
+	"gpt-4o-transcribe":      {},
+	"gpt-4o-mini-audio":      {},
+	"gpt-4o-mini-realtime":   {},
+	"gpt-4o-mini-transcribe": {},
+	"gpt-4o-mini-tts":        {},
+}
+
+// CapabilitiesOf returns the capabilities of a model, falling back to
+// DefaultCapabilities.
+func CapabilitiesOf(model string) Capabilities {
+	return lookupModel(capabilities, model, DefaultCapabilities)
+}
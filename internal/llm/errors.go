@@ -0,0 +1,19 @@
+package llm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMalformedResponse is returned when an API response has no usable content.
+var ErrMalformedResponse = errors.New("malformed response")
+
+// APIError is a non-200 response from an LLM API.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+}
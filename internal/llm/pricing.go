@@ -0,0 +1,72 @@
+package llm
+
+import "strings"
+
+// Price is the cost of a model in USD per million tokens.
+type Price struct {
+	Input  float64
+	Output float64
+}
+
+// DefaultPrice is used for models missing from the price table. It errs on
+// the expensive side so budgets stay conservative.
+var DefaultPrice = Price{Input: 3, Output: 15}
+
+// prices maps model ID prefixes to list prices; the longest matching prefix wins.
+var prices = map[string]Price{
+	"claude-opus":   {Input: 15, Output: 75},
+	"claude-sonnet": {Input: 3, Output: 15},
+	"claude-haiku":  {Input: 0.8, Output: 4},
+	"gpt-4o":        {Input: 2.5, Output: 10},
+	"gpt-4o-mini":   {Input: 0.15, Output: 0.6},
+	"gpt-4.1":       {Input: 2, Output: 8},
+	"gpt-4.1-mini":  {Input: 0.4, Output: 1.6},
+	"gpt-4.1-nano":  {Input: 0.1, Output: 0.4},
+
+	"text-embedding-3-small": {Input: 0.02},
+	"text-embedding-3-large": {Input: 0.13},
+	"text-embedding-ada-002": {Input: 0.1},
+}
+
+// PriceFor returns the price of a model, falling back to DefaultPrice.
+func PriceFor(model string) Price {
+	return lookupModel(prices, model, DefaultPrice)
+}
+
+// lookupModel returns the table entry with the longest prefix of model,
+// ignoring case and any provider prefix such as "openai/", or fallback.
+func lookupModel[T any](table map[string]T, model string, fallback T) T {
+	model = BareModelID(model)
+	best, bestLen := fallback, 0
+	for prefix, v := range table {
+		if strings.HasPrefix(model, prefix) && len(prefix) > bestLen {
+			best, bestLen = v, len(prefix)
+		}
+	}
+	return best
+}
+
+// BareModelID lowercases a model ID and strips a provider prefix such as
+// "openai/gpt-4o".
+func BareModelID(model string) string {
+	model = strings.ToLower(model)
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+	return model
+}
+
+// EstimateTokens approximates the token count of text (about four characters
+// per token). Backends do not report usage, so budgets rely on this estimate.
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	return (len(text) + 3) / 4
+}
+
+// EstimateCost returns the estimated USD cost of a call to model.
+func EstimateCost(model string, inputTokens, outputTokens int) float64 {
+	p := PriceFor(model)
+	return (float64(inputTokens)*p.Input + float64(outputTokens)*p.Output) / 1e6
+}
@@ -27,3 +27,9 @@ type StreamingClient interface {
 type ToolCallingClient interface {
 	SendMessageWithTools(messages []Message, systemPrompt string, temperature float64, tools []ToolDefinition, executeTool func(name, arguments string) (string, error)) (string, error)
 }
+
+// EmbeddingClient is an optional interface for backends that can embed text into vectors.
+// Detect with: ec, ok := client.(llm.EmbeddingClient)
+type EmbeddingClient interface {
+	Embed(texts []string) ([][]float64, error)
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Budget caps what a discussion (or a single agent) may spend. Zero fields
+// are unlimited. Token counts and costs are estimates.
+type Budget struct {
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+	MaxCost     float64       `json:"max_cost,omitempty"` // estimated USD
+	MaxDuration time.Duration `json:"max_duration,omitempty"`
+}
+
+// IsZero reports whether the budget sets no limits
+func (b Budget) IsZero() bool {
+	return b.MaxTokens <= 0 && b.MaxCost <= 0 && b.MaxDuration <= 0
+}
+
+// Fraction returns the largest share of any limit that usage has consumed,
+// or 0 when the budget sets no limits.
+func (b Budget) Fraction(u BudgetUsage) float64 {
+	var f float64
+	if b.MaxTokens > 0 {
+		f = max(f, float64(u.Tokens)/float64(b.MaxTokens))
+	}
+	if b.MaxCost > 0 {
+		f = max(f, u.Cost/b.MaxCost)
+	}
+	if b.MaxDuration > 0 {
+		f = max(f, float64(u.Duration)/float64(b.MaxDuration))
+	}
+	return f
+}
+
+// Min returns the tighter of two budgets, limit by limit
+func (b Budget) Min(other Budget) Budget {
+	tighter := func(x, y float64) float64 {
+		if x <= 0 || (y > 0 && y < x) {
+			return y
+		}
+		return x
+	}
+	return Budget{
+		MaxTokens:   int(tighter(float64(b.MaxTokens), float64(other.MaxTokens))),
+		MaxCost:     tighter(b.MaxCost, other.MaxCost),
+		MaxDuration: time.Duration(tighter(float64(b.MaxDuration), float64(other.MaxDuration))),
+	}
+}
+
+// BudgetFromEnv reads a budget from <prefix>_MAX_TOKENS, <prefix>_MAX_COST
+// (USD) and <prefix>_MAX_MINUTES. Unset or invalid values are unlimited.
+func BudgetFromEnv(prefix string) Budget {
+	num := func(name string) float64 {
+		v, err := strconv.ParseFloat(os.Getenv(prefix+"_"+name), 64)
+		if err != nil || v < 0 {
+			return 0
+		}
+		return v
+	}
+	return Budget{
+		MaxTokens:   int(num("MAX_TOKENS")),
+		MaxCost:     num("MAX_COST"),
+		MaxDuration: time.Duration(num("MAX_MINUTES") * float64(time.Minute)),
+	}
+}
+
+// BudgetUsage is the estimated spend so far
+type BudgetUsage struct {
+	Tokens   int           `json:"tokens"`
+	Cost     float64       `json:"cost"` // estimated USD
+	Duration time.Duration `json:"duration"`
+	Calls    int           `json:"calls"`
+}
+
+// Budget levels, in order of increasing spend. Each level degrades the
+// discussion further: skip optional agents, stop starting new rounds, jump
+// to validation and report, and finally make no further LLM calls.
+const (
+	BudgetOK        = "ok"
+	BudgetLow       = "low"       // optional agents, extra passes, deep dives, tournament and extra judges skipped
+	BudgetCritical  = "critical"  // no new exploration rounds start
+	BudgetReserve   = "reserve"   // remaining exploration skipped; straight to validation and report
+	BudgetExhausted = "exhausted" // limit reached; no further LLM calls
+)
+
+// BudgetStatus records budget limits and spend on a discussion
+type BudgetStatus struct {
+	Limits      Budget                 `json:"limits"`
+	AgentLimits Budget                 `json:"agent_limits"`
+	Used        BudgetUsage            `json:"used"`
+	AgentUsage  map[string]BudgetUsage `json:"agent_usage,omitempty"` // role → spend
+	Fraction    float64                `json:"fraction"`              // largest share of any discussion limit used
+	Level       string                 `json:"level"`                 // Budget* level
+
+	// Degradations lists the steps skipped or cut short to stay within budget
+	Degradations []string `json:"degradations,omitempty"`
+
+	// AgentsOverBudget lists roles that hit their per-agent limit and were benched
+	AgentsOverBudget []string `json:"agents_over_budget,omitempty"`
+}
+
+// Clone returns a deep copy of the status
+func (s *BudgetStatus) Clone() *BudgetStatus {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	c.AgentUsage = maps.Clone(s.AgentUsage)
+	c.Degradations = slices.Clone(s.Degradations)
+	c.AgentsOverBudget = slices.Clone(s.AgentsOverBudget)
+	return &c
+}
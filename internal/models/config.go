@@ -1,5 +1,11 @@
 package models
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // TeamConfig defines the configuration for the agent team
 type TeamConfig struct {
 	// Core agents (always included)
@@ -18,12 +24,203 @@ type TeamConfig struct {
 	IdeationCount int  // Number of ideation passes per round (1-3)
 	MinIdeas      int  // Minimum ideas to generate
 	DeepDive      bool // Enable deep dive mode with more back-and-forth
+	DeepDiveTopK  int  // Number of top ideas examined in deep dive mode (default 3)
+	DedupIdeas    bool // Merge near-duplicate ideas after each ideation pass
+
+	// Adaptive round count
+	AdaptiveRounds bool // Stop early once the discussion converges, or extend past MaxRounds when it hasn't
+	MinRounds      int  // Rounds always run before convergence may stop the discussion (default 1)
+	RoundCap       int  // Hard cap on rounds in adaptive mode (default 2×MaxRounds)
+
+	// Leader-directed turn-taking: the leader picks each next speaker in
+	// exploration instead of the fixed researcher → ideation → critic → implementer order
+	DynamicTurns     bool
+	MaxTurnsPerRound int // Turn cap per round in dynamic mode (default 2× the exploration participants)
+
+	// StrictFailures fails the discussion when an agent contribution fails,
+	// instead of recording the failure and continuing without it
+	StrictFailures bool
+
+	// Cross-session memory: recall related past discussions for the leader
+	// and ideation agent, and remember this one when it completes
+	TeamMemory bool
+
+	// Pairwise ranking
+	Tournament       bool // Rank ideas head-to-head before selection and pick the winner by rating
+	TournamentRounds int  // Swiss rounds in the tournament (default 3)
+
+	// Judging panel: extra moderators that score alongside the moderator in final validation
+	Judges           []JudgeConfig
+	ScoreAggregation string // AggregateMean, AggregateMedian or AggregateTrimmedMean (default mean)
+
+	// Scoring criteria and their weights (default DefaultCriteria)
+	Criteria []Criterion
+
+	// Extra agent roles loaded from definition files
+	CustomRoles []CustomRole
+
+	// Roles registered by other packages via agents.Register to include
+	ExtraRoles []AgentRole
+
+	// Spending limits for the whole discussion and for each agent (zero = unlimited)
+	Budget      Budget
+	AgentBudget Budget
 
 	// Quality settings
 	MinScoreThreshold float64 // Minimum score for ideas to be considered
 
-	// Per-agent model selection (populated by team leader or user)
+	// Per-agent model selection (populated by model assignment or user)
 	AgentModels map[AgentRole]string // e.g. {RoleIdeation: "gpt-4o", RoleCritic: "claude-sonnet-4-20250514"}
+
+	// How models are assigned before kickoff: ModelAssignPolicy (default)
+	// applies ModelPolicy deterministically; ModelAssignLLM asks the team leader
+	ModelAssignment string
+	ModelPolicy     ModelPolicy
+}
+
+// Phases a custom role can join
+const (
+	CustomPhaseExploration = "exploration" // contributes each round after the built-in agents
+	CustomPhaseDeepDive    = "deep_dive"   // adds a step to each idea's deep dive mini-round
+)
+
+// CustomRole is an agent role defined in a definition file rather than Go
+type CustomRole struct {
+	Role         AgentRole
+	Name         string
+	Persona      string // one-line description of the role
+	SystemPrompt string
+	Temperature  float64
+	Tools        []string          // tool names the agent may call, e.g. "web_search"
+	OutputSchema string            // optional JSON the agent must answer with; an "ideas" array becomes ideas
+	Phases       map[string]string // phase (CustomPhase*) → task prompt
+	Source       string            // file the role was loaded from
+}
+
+// Criterion is one dimension ideas are scored on, with its relative weight
+type Criterion struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Weight      float64 `json:"weight"`
+}
+
+// DefaultCriteria returns the moderator's standard evaluation criteria, equally weighted
+func DefaultCriteria() []Criterion {
+	return []Criterion{
+		{Name: "Feasibility", Description: "Can this be realistically implemented?", Weight: 1},
+		{Name: "Innovation", Description: "Is this creative and differentiated?", Weight: 1},
+		{Name: "Impact", Description: "What value does this provide?", Weight: 1},
+		{Name: "Clarity", Description: "Is the idea well-defined and understandable?", Weight: 1},
+		{Name: "Completeness", Description: "Is the idea fully thought through?", Weight: 1},
+	}
+}
+
+// ParseCriteria reads comma-separated criteria, each a name with an optional
+// "=weight", e.g. "Feasibility=2,Impact,Cost=0.5". See NormalizeCriteria.
+func ParseCriteria(s string) ([]Criterion, error) {
+	var criteria []Criterion
+	for _, field := range strings.Split(s, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		name, weight, hasWeight := strings.Cut(field, "=")
+		c := Criterion{Name: name}
+		if hasWeight {
+			w, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+			if err != nil {
+				return nil, fmt.Errorf("criterion %q: invalid weight %q", strings.TrimSpace(name), weight)
+			}
+			c.Weight = w
+		}
+		criteria = append(criteria, c)
+	}
+	return NormalizeCriteria(criteria)
+}
+
+// NormalizeCriteria checks user-supplied criteria and fills in defaults: a
+// zero weight becomes 1 and a default criterion's description is used when
+// none is given. Names must be non-empty and unique (ignoring case), and
+// weights must not be negative.
+func NormalizeCriteria(criteria []Criterion) ([]Criterion, error) {
+	defaults := make(map[string]string)
+	for _, c := range DefaultCriteria() {
+		defaults[strings.ToLower(c.Name)] = c.Description
+	}
+	seen := make(map[string]bool)
+	out := make([]Criterion, 0, len(criteria))
+	for _, c := range criteria {
+		c.Name = strings.TrimSpace(c.Name)
+		key := strings.ToLower(c.Name)
+		switch {
+		case c.Name == "":
+			return nil, fmt.Errorf("criterion without a name")
+		case seen[key]:
+			return nil, fmt.Errorf("duplicate criterion %q", c.Name)
+		case c.Weight < 0:
+			return nil, fmt.Errorf("criterion %q: negative weight", c.Name)
+		}
+		seen[key] = true
+		if c.Weight == 0 {
+			c.Weight = 1
+		}
+		if c.Description == "" {
+			c.Description = defaults[key]
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// WeightedScore computes the overall 0-10 score from per-criterion scores.
+// Criteria missing from scores are left out of the weighting; it returns
+// false if none of the criteria were scored.
+func WeightedScore(scores map[string]float64, criteria []Criterion) (float64, bool) {
+	var sum, weights float64
+	for _, c := range criteria {
+		s, ok := scores[c.Name]
+		if !ok || c.Weight <= 0 {
+			continue
+		}
+		sum += s * c.Weight
+		weights += c.Weight
+	}
+	if weights == 0 {
+		return 0, false
+	}
+	return sum / weights, true
+}
+
+// Score aggregation methods for a judging panel
+const (
+	AggregateMean        = "mean"
+	AggregateMedian      = "median"
+	AggregateTrimmedMean = "trimmed_mean"
+)
+
+// IsAggregation reports whether method is a known score aggregation method
+func IsAggregation(method string) bool {
+	switch method {
+	case AggregateMean, AggregateMedian, AggregateTrimmedMean:
+		return true
+	}
+	return false
+}
+
+// JudgeConfig describes an extra judge on the final validation panel
+type JudgeConfig struct {
+	Name    string `json:"name"`              // Display name, e.g. "Pragmatist"
+	Model   string `json:"model,omitempty"`   // Model override (empty = the moderator's model)
+	Persona string `json:"persona,omitempty"` // Evaluation lens added to the moderator prompt
+}
+
+// DefaultJudges returns three judges with distinct lenses. With the
+// moderator they form a four-member panel.
+func DefaultJudges() []JudgeConfig {
+	return []JudgeConfig{
+		{Name: "Pragmatist", Persona: "Weigh feasibility, cost and time to value above novelty."},
+		{Name: "Visionary", Persona: "Weigh originality and long-term impact above short-term practicality."},
+		{Name: "Skeptic", Persona: "Look for the weakest assumption in each idea and score it down when it is unsupported."},
+	}
 }
 
 // DefaultTeamConfig returns a standard team configuration
@@ -40,6 +237,15 @@ func DefaultTeamConfig() *TeamConfig {
 		IdeationCount:      1,
 		MinIdeas:           3,
 		DeepDive:           false,
+		DeepDiveTopK:       3,
+		DedupIdeas:         true,
+		AdaptiveRounds:     false,
+		MinRounds:          1,
+		Tournament:         false,
+		TournamentRounds:   3,
+		DynamicTurns:       false,
+		TeamMemory:         false,
+		StrictFailures:     false,
 		MinScoreThreshold:  6.0,
 	}
 }
@@ -58,6 +264,15 @@ func StandardTeamConfig() *TeamConfig {
 		IdeationCount:      1,
 		MinIdeas:           3,
 		DeepDive:           false,
+		DeepDiveTopK:       3,
+		DedupIdeas:         true,
+		AdaptiveRounds:     false,
+		MinRounds:          1,
+		Tournament:         false,
+		TournamentRounds:   3,
+		DynamicTurns:       false,
+		TeamMemory:         false,
+		StrictFailures:     false,
 		MinScoreThreshold:  6.0,
 	}
 }
@@ -76,6 +291,15 @@ func ExtendedTeamConfig() *TeamConfig {
 		IdeationCount:      1,
 		MinIdeas:           4,
 		DeepDive:           true,
+		DeepDiveTopK:       3,
+		DedupIdeas:         true,
+		AdaptiveRounds:     false,
+		MinRounds:          1,
+		Tournament:         false,
+		TournamentRounds:   3,
+		DynamicTurns:       false,
+		TeamMemory:         false,
+		StrictFailures:     false,
 		MinScoreThreshold:  7.0,
 	}
 }
@@ -94,10 +318,33 @@ func FullTeamConfig() *TeamConfig {
 		IdeationCount:      1,
 		MinIdeas:           5,
 		DeepDive:           true,
+		DeepDiveTopK:       3,
+		DedupIdeas:         true,
+		AdaptiveRounds:     false,
+		MinRounds:          1,
+		Tournament:         false,
+		TournamentRounds:   3,
+		DynamicTurns:       false,
+		TeamMemory:         false,
+		StrictFailures:     false,
 		MinScoreThreshold:  7.5,
 	}
 }
 
+// PresetTeamConfig returns the preset team called name ("standard",
+// "extended" or "full", ignoring case), or false if there is none.
+func PresetTeamConfig(name string) (*TeamConfig, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "standard":
+		return StandardTeamConfig(), true
+	case "extended":
+		return ExtendedTeamConfig(), true
+	case "full":
+		return FullTeamConfig(), true
+	}
+	return nil, false
+}
+
 // GetActiveAgentRoles returns a list of active agent roles based on config
 func (c *TeamConfig) GetActiveAgentRoles() []AgentRole {
 	var roles []AgentRole
@@ -120,6 +367,10 @@ func (c *TeamConfig) GetActiveAgentRoles() []AgentRole {
 	if c.IncludeImplementer {
 		roles = append(roles, RoleImplementer)
 	}
+	for _, cr := range c.CustomRoles {
+		roles = append(roles, cr.Role)
+	}
+	roles = append(roles, c.ExtraRoles...)
 	if c.IncludeUICreator {
 		roles = append(roles, RoleUICreator)
 	}
@@ -131,3 +382,47 @@ func (c *TeamConfig) GetActiveAgentRoles() []AgentRole {
 func (c *TeamConfig) TeamSize() int {
 	return len(c.GetActiveAgentRoles())
 }
+
+// RoundLimits returns the minimum and maximum number of rounds to run.
+// Without adaptive rounds both equal MaxRounds.
+func (c *TeamConfig) RoundLimits() (minRounds, maxRounds int) {
+	if !c.AdaptiveRounds {
+		return c.MaxRounds, c.MaxRounds
+	}
+
+	rounds := c.MaxRounds
+	if rounds < 1 {
+		rounds = 1
+	}
+
+	minRounds = c.MinRounds
+	if minRounds < 1 {
+		minRounds = 1
+	}
+	maxRounds = c.RoundCap
+	if maxRounds < 1 {
+		maxRounds = 2 * rounds
+	}
+	if maxRounds < minRounds {
+		maxRounds = minRounds
+	}
+	return minRounds, maxRounds
+}
+
+// ScoringCriteria returns the configured criteria, or DefaultCriteria if none are set
+func (c *TeamConfig) ScoringCriteria() []Criterion {
+	if len(c.Criteria) == 0 {
+		return DefaultCriteria()
+	}
+	return c.Criteria
+}
+
+// CustomRole returns the custom role definition for role, if any
+func (c *TeamConfig) CustomRole(role AgentRole) (CustomRole, bool) {
+	for _, cr := range c.CustomRoles {
+		if cr.Role == role {
+			return cr, true
+		}
+	}
+	return CustomRole{}, false
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+// TestWeightedScore checks the weighted average skips criteria that were not
+// scored or carry no weight, and reports when nothing could be weighted.
+func TestWeightedScore(t *testing.T) {
+	criteria := []Criterion{
+		{Name: "Feasibility", Weight: 2},
+		{Name: "Impact", Weight: 1},
+		{Name: "Novelty", Weight: 0},
+	}
+	tests := []struct {
+		name   string
+		scores map[string]float64
+		want   float64
+		wantOK bool
+	}{
+		{"all scored", map[string]float64{"Feasibility": 9, "Impact": 6, "Novelty": 1}, 8, true},
+		{"missing criterion left out", map[string]float64{"Impact": 6}, 6, true},
+		{"zero weight ignored", map[string]float64{"Feasibility": 5, "Novelty": 10}, 5, true},
+		{"unknown criterion ignored", map[string]float64{"Feasibility": 7, "Cost": 1}, 7, true},
+		{"only zero weight scored", map[string]float64{"Novelty": 10}, 0, false},
+		{"nothing scored", nil, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := WeightedScore(tt.scores, criteria)
+		if ok != tt.wantOK || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: WeightedScore = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// TestParseCriteria checks weights default to 1, default criteria keep
+// their descriptions, and malformed lists are rejected.
+func TestParseCriteria(t *testing.T) {
+	got, err := ParseCriteria(" Feasibility=2, Cost ,impact=0.5,")
+	if err != nil {
+		t.Fatalf("ParseCriteria: %v", err)
+	}
+	want := []Criterion{
+		{Name: "Feasibility", Description: "Can this be realistically implemented?", Weight: 2},
+		{Name: "Cost", Weight: 1},
+		{Name: "impact", Description: "What value does this provide?", Weight: 0.5},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ParseCriteria = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("criterion %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if got, err := ParseCriteria(""); err != nil || len(got) != 0 {
+		t.Errorf("ParseCriteria(\"\") = %v, %v; want no criteria", got, err)
+	}
+	for _, bad := range []string{"Cost=cheap", "Cost,cost", "=2", "Cost=-1"} {
+		if _, err := ParseCriteria(bad); err == nil {
+			t.Errorf("ParseCriteria(%q) succeeded, want an error", bad)
+		}
+	}
+}
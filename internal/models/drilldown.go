@@ -0,0 +1,228 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	maxSeedEvidence  = 800 // characters kept per evidence excerpt
+	maxOpenQuestions = 6
+)
+
+// DiscussionSeed carries a finished discussion's outcome into a drill-down
+// follow-up discussion
+type DiscussionSeed struct {
+	ParentID      string   `json:"parent_id"`
+	ParentTopic   string   `json:"parent_topic"`
+	Idea          Idea     `json:"idea"`                     // the parent's final idea, with pros and cons
+	Evidence      []string `json:"evidence,omitempty"`       // research excerpts supporting or challenging the idea
+	OpenQuestions []string `json:"open_questions,omitempty"` // questions the parent left unanswered
+	Depth         int      `json:"depth"`                    // 1 for a drill-down of a root discussion
+}
+
+// questionPattern matches a sentence ending in a question mark
+var questionPattern = regexp.MustCompile(`[^.!?\n]*[A-Za-z][^.!?\n]*\?`)
+
+// NewDrillDownSeed builds the seed for a follow-up discussion on parent's
+// final idea. The parent must have selected one.
+func NewDrillDownSeed(parent *Discussion) (*DiscussionSeed, error) {
+	if parent == nil || parent.FinalIdea == nil {
+		return nil, fmt.Errorf("discussion has no final idea to drill into")
+	}
+	idea := *parent.FinalIdea
+	seed := &DiscussionSeed{
+		ParentID:    parent.ID,
+		ParentTopic: parent.Topic,
+		Idea:        idea,
+		Depth:       1,
+	}
+	if parent.Seed != nil {
+		seed.Depth = parent.Seed.Depth + 1
+	}
+
+	var questionSources []string
+	if dd := idea.DeepDive; dd != nil {
+		if dd.Evidence != "" {
+			seed.Evidence = append(seed.Evidence, excerpt(dd.Evidence, maxSeedEvidence))
+		}
+		questionSources = append(questionSources, dd.Risks)
+	}
+	// The most recent researcher contribution, when the deep dive gathered none
+	if len(seed.Evidence) == 0 {
+		for i := len(parent.Messages) - 1; i >= 0; i-- {
+			if m := parent.Messages[i]; m.From == string(RoleResearcher) {
+				seed.Evidence = append(seed.Evidence, excerpt(m.Content, maxSeedEvidence))
+				break
+			}
+		}
+	}
+	for _, m := range parent.Messages {
+		if m.From == string(RoleCritic) && m.Type != MessageQuestion {
+			// The trailing JSON risk register is already covered by idea.Risks,
+			// and directed questions, quoted or recorded, by UnansweredQuestions
+			prose, _, _ := strings.Cut(m.Content, "{")
+			questionSources = append(questionSources, DirectedQuestionPattern.ReplaceAllString(prose, ""))
+		}
+	}
+
+	// Open questions from the risk register come first, most serious risk first
+	seen := make(map[string]bool)
+	for _, r := range SortRisks(idea.Risks) {
+		key := strings.ToLower(r.OpenQuestion)
+		if r.OpenQuestion == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		seed.OpenQuestions = append(seed.OpenQuestions, r.OpenQuestion)
+		if len(seed.OpenQuestions) == maxOpenQuestions {
+			return seed, nil
+		}
+	}
+	// Then questions teammates asked and never got answered
+	for _, q := range parent.UnansweredQuestions() {
+		key := strings.ToLower(q.Content)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		seed.OpenQuestions = append(seed.OpenQuestions, q.Content)
+		if len(seed.OpenQuestions) == maxOpenQuestions {
+			return seed, nil
+		}
+	}
+	for _, src := range questionSources {
+		for _, q := range questionPattern.FindAllString(src, -1) {
+			q = strings.TrimLeft(strings.TrimSpace(q), "-*•#0123456789. ")
+			key := strings.ToLower(q)
+			if len(q) < 15 || seen[key] {
+				continue
+			}
+			seen[key] = true
+			seed.OpenQuestions = append(seed.OpenQuestions, q)
+			if len(seed.OpenQuestions) == maxOpenQuestions {
+				return seed, nil
+			}
+		}
+	}
+	return seed, nil
+}
+
+// DefaultTopic is the follow-up topic used when none is given
+func (s *DiscussionSeed) DefaultTopic() string {
+	return fmt.Sprintf("How do we make %q happen?", s.Idea.Title)
+}
+
+// Context renders the seed as background for the agents
+func (s *DiscussionSeed) Context() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "This is a drill-down (level %d) of an earlier discussion on %q, which selected:\n", s.Depth, s.ParentTopic)
+	fmt.Fprintf(&b, "%s - %s\n", s.Idea.Title, s.Idea.Description)
+	if s.Idea.Score > 0 {
+		fmt.Fprintf(&b, "Score: %.1f/10\n", s.Idea.Score)
+	}
+	if len(s.Idea.Pros) > 0 {
+		fmt.Fprintf(&b, "Pros: %s\n", strings.Join(s.Idea.Pros, "; "))
+	}
+	if len(s.Idea.Cons) > 0 {
+		fmt.Fprintf(&b, "Cons: %s\n", strings.Join(s.Idea.Cons, "; "))
+	}
+	for _, e := range s.Evidence {
+		fmt.Fprintf(&b, "Evidence: %s\n", e)
+	}
+	if len(s.OpenQuestions) > 0 {
+		b.WriteString("Open questions:\n")
+		for _, q := range s.OpenQuestions {
+			fmt.Fprintf(&b, "- %s\n", q)
+		}
+	}
+	b.WriteString("Take this idea as given: explore how to realise it and answer the open questions rather than proposing unrelated alternatives.\n")
+	return b.String()
+}
+
+// excerpt truncates s to max characters on a word boundary
+func excerpt(s string, max int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= max {
+		return s
+	}
+	cut := s[:max]
+	if i := strings.LastIndexAny(cut, " \n"); i > max/2 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
+
+// DiscussionNode is one discussion in a drill-down tree
+type DiscussionNode struct {
+	ID        string            `json:"id"`
+	Topic     string            `json:"topic"`
+	Status    string            `json:"status"`
+	FinalIdea string            `json:"final_idea,omitempty"`
+	Children  []*DiscussionNode `json:"children,omitempty"`
+}
+
+// BuildDiscussionTree returns the drill-down tree containing the discussion
+// id, rooted at its oldest known ancestor. lookup returns nil for unknown IDs.
+func BuildDiscussionTree(id string, lookup func(id string) *Discussion) *DiscussionNode {
+	d := lookup(id)
+	if d == nil {
+		return nil
+	}
+	visited := map[string]bool{d.ID: true}
+	for d.ParentID != "" && !visited[d.ParentID] {
+		parent := lookup(d.ParentID)
+		if parent == nil {
+			break
+		}
+		visited[parent.ID] = true
+		d = parent
+	}
+	return buildNode(d, lookup, make(map[string]bool))
+}
+
+func buildNode(d *Discussion, lookup func(id string) *Discussion, visited map[string]bool) *DiscussionNode {
+	visited[d.ID] = true
+	node := &DiscussionNode{ID: d.ID, Topic: d.Topic, Status: d.Status}
+	if d.FinalIdea != nil {
+		node.FinalIdea = d.FinalIdea.Title
+	}
+	for _, cid := range d.ChildIDs {
+		if visited[cid] {
+			continue
+		}
+		if child := lookup(cid); child != nil {
+			node.Children = append(node.Children, buildNode(child, lookup, visited))
+		}
+	}
+	return node
+}
+
+// Render draws the tree as indented text, one discussion per line
+func (n *DiscussionNode) Render() string {
+	var b strings.Builder
+	n.render(&b, "", "")
+	return b.String()
+}
+
+func (n *DiscussionNode) render(b *strings.Builder, prefix, branch string) {
+	line := n.Topic
+	if n.FinalIdea != "" {
+		line += " → " + n.FinalIdea
+	}
+	fmt.Fprintf(b, "%s%s%s [%s]\n", prefix, branch, line, n.Status)
+	switch branch {
+	case "├─ ":
+		prefix += "│  "
+	case "└─ ":
+		prefix += "   "
+	}
+	for i, c := range n.Children {
+		if i == len(n.Children)-1 {
+			c.render(b, prefix, "└─ ")
+		} else {
+			c.render(b, prefix, "├─ ")
+		}
+	}
+}
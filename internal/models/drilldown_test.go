@@ -0,0 +1,107 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// drillDownParent is a finished drill-down discussion whose final idea has a
+// risk register and no deep dive
+func drillDownParent() *Discussion {
+	final := Idea{ID: "i1", Title: "Community fridges", Risks: []Risk{
+		{Category: "legal", Description: "Food safety liability", Severity: RiskLow, Likelihood: RiskLow, OpenQuestion: "Who is liable if someone gets ill?"},
+		{Category: "operational", Description: "Fridges left dirty", Severity: RiskHigh, Likelihood: RiskHigh, OpenQuestion: "Who cleans the fridges every week?"},
+		{Category: "operational", Description: "Fridges overflow", Severity: RiskHigh, Likelihood: RiskLow, OpenQuestion: "who cleans the fridges every week?"},
+	}}
+	return &Discussion{
+		ID:        "parent",
+		Topic:     "food waste",
+		FinalIdea: &final,
+		Seed:      &DiscussionSeed{Depth: 1},
+		Messages: []Message{
+			{ID: "m1", From: string(RoleResearcher), Content: "Older findings."},
+			{ID: "m2", From: string(RoleResearcher), Content: "  Fridges in Berlin cut waste by 20%.  "},
+			{ID: "m3", From: string(RoleCritic), Content: "Hosts may drop out. How will we recruit replacement hosts?\n" +
+				"QUESTION @implementer: How long does an install take per site?\n" +
+				`{"risks": [{"open_question": "Is the JSON register skipped here?"}]}`},
+			{ID: "m4", From: string(RoleCritic), To: string(RoleImplementer), Type: MessageQuestion, ReplyTo: "m3", Content: "How long does an install take per site?"},
+			{ID: "m5", From: string(RoleImplementer), Type: MessageResponse, ReplyTo: "m4", Content: "About a day."},
+			{ID: "m6", From: string(RoleCritic), To: string(RoleResearcher), Type: MessageQuestion, Content: "Do other cities fund fridges publicly?"},
+		},
+	}
+}
+
+// TestNewDrillDownSeed checks the seed carries the final idea one level
+// deeper, takes the latest research as evidence, and orders open questions
+// by risk rating, then unanswered directed questions, then critic prose —
+// without repeating directed questions that were already answered.
+func TestNewDrillDownSeed(t *testing.T) {
+	parent := drillDownParent()
+	seed, err := NewDrillDownSeed(parent)
+	if err != nil {
+		t.Fatalf("NewDrillDownSeed: %v", err)
+	}
+	if seed.ParentID != "parent" || seed.ParentTopic != "food waste" || seed.Idea.ID != "i1" || seed.Depth != 2 {
+		t.Errorf("seed = %s/%q idea %s depth %d, want parent/\"food waste\" idea i1 depth 2", seed.ParentID, seed.ParentTopic, seed.Idea.ID, seed.Depth)
+	}
+	if want := []string{"Fridges in Berlin cut waste by 20%."}; !reflect.DeepEqual(seed.Evidence, want) {
+		t.Errorf("evidence = %q, want %q", seed.Evidence, want)
+	}
+	want := []string{
+		"Who cleans the fridges every week?",
+		"Who is liable if someone gets ill?",
+		"Do other cities fund fridges publicly?",
+		"How will we recruit replacement hosts?",
+	}
+	if !reflect.DeepEqual(seed.OpenQuestions, want) {
+		t.Errorf("open questions = %q\nwant %q", seed.OpenQuestions, want)
+	}
+
+	parent.Seed = nil
+	if seed, _ := NewDrillDownSeed(parent); seed.Depth != 1 {
+		t.Errorf("depth of a root's drill-down = %d, want 1", seed.Depth)
+	}
+}
+
+// TestNewDrillDownSeedDeepDive checks deep-dive evidence replaces the
+// researcher's messages, is truncated on a word boundary, and that the deep
+// dive's risk prose supplies questions up to maxOpenQuestions.
+func TestNewDrillDownSeedDeepDive(t *testing.T) {
+	parent := drillDownParent()
+	parent.FinalIdea.DeepDive = &DeepDiveResult{
+		Evidence: strings.Repeat("evidence ", 200),
+		Risks:    "1. Will hosts keep the fridges stocked in winter?\n2. Can councils waive the permit fees?\n3. Too short?",
+	}
+	seed, err := NewDrillDownSeed(parent)
+	if err != nil {
+		t.Fatalf("NewDrillDownSeed: %v", err)
+	}
+	if len(seed.Evidence) != 1 || !strings.HasPrefix(seed.Evidence[0], "evidence ") || !strings.HasSuffix(seed.Evidence[0], "evidence…") {
+		t.Fatalf("evidence = %q, want one deep-dive excerpt cut after a word", seed.Evidence)
+	}
+	if n := len(strings.TrimSuffix(seed.Evidence[0], "…")); n > maxSeedEvidence {
+		t.Errorf("evidence excerpt is %d characters, want at most %d", n, maxSeedEvidence)
+	}
+	want := []string{
+		"Who cleans the fridges every week?",
+		"Who is liable if someone gets ill?",
+		"Do other cities fund fridges publicly?",
+		"Will hosts keep the fridges stocked in winter?",
+		"Can councils waive the permit fees?",
+		"How will we recruit replacement hosts?",
+	}
+	if !reflect.DeepEqual(seed.OpenQuestions, want) {
+		t.Errorf("open questions = %q\nwant %q", seed.OpenQuestions, want)
+	}
+}
+
+// TestNewDrillDownSeedNoFinalIdea checks a discussion without a final idea
+// cannot be drilled into
+func TestNewDrillDownSeedNoFinalIdea(t *testing.T) {
+	for _, parent := range []*Discussion{nil, {ID: "open", Topic: "food waste"}} {
+		if seed, err := NewDrillDownSeed(parent); err == nil {
+			t.Errorf("NewDrillDownSeed(%v) = %+v, want an error", parent, seed)
+		}
+	}
+}
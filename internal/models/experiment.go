@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+// ExperimentArm is one configuration an experiment runs the topic under.
+// Model, when set, replaces the backend's default model for the arm.
+type ExperimentArm struct {
+	Name   string      `json:"name"`
+	Config *TeamConfig `json:"-"`
+	Model  string      `json:"model,omitempty"`
+}
+
+// ExperimentResult compares the discussions an experiment ran on one topic,
+// one per arm. The first arm is the baseline that CostRatio and ScoreDelta
+// are measured against.
+type ExperimentResult struct {
+	ID        string    `json:"id"`
+	Topic     string    `json:"topic"`
+	Status    string    `json:"status"` // running, completed
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time,omitempty"`
+	Budget    Budget    `json:"budget"` // shared by all arms; zero fields are unlimited
+
+	Arms     []ArmResult   `json:"arms"`
+	Overlaps []IdeaOverlap `json:"overlaps,omitempty"` // one per pair of completed arms
+
+	// SamePick is true when every completed arm selected a similar final idea
+	SamePick bool `json:"same_pick"`
+}
+
+// ArmResult is the outcome of one arm's discussion
+type ArmResult struct {
+	Name         string        `json:"name"`
+	Model        string        `json:"model"`
+	Agents       int           `json:"agents"`
+	DiscussionID string        `json:"discussion_id"`
+	Status       string        `json:"status"` // pending, running, completed, failed
+	Error        string        `json:"error,omitempty"`
+	Rounds       int           `json:"rounds"`
+	StopReason   string        `json:"stop_reason,omitempty"`
+	Ideas        int           `json:"ideas"`
+	MeanScore    float64       `json:"mean_score"` // mean score of the validated ideas
+	TopScore     float64       `json:"top_score"`
+	FinalIdea    string        `json:"final_idea,omitempty"`
+	FinalScore   float64       `json:"final_score,omitempty"`
+	Cost         float64       `json:"cost"` // estimated USD
+	Tokens       int           `json:"tokens"`
+	Calls        int           `json:"calls"`
+	Duration     time.Duration `json:"duration"`
+	Failures     int           `json:"failures"`              // agent contributions that failed
+	CostRatio    float64       `json:"cost_ratio,omitempty"`  // cost relative to the baseline arm
+	ScoreDelta   float64       `json:"score_delta,omitempty"` // final score minus the baseline's
+
+	// Discussion is the arm's full discussion, for saving or drilling into
+	Discussion *Discussion `json:"-"`
+}
+
+// IdeaOverlap is how far two arms arrived at the same ideas. Ideas from
+// separate discussions never share IDs, so they are matched by content.
+type IdeaOverlap struct {
+	A          string      `json:"a"` // arm names
+	B          string      `json:"b"`
+	Shared     int         `json:"shared"`     // ideas of A with a similar idea in B
+	Similarity float64     `json:"similarity"` // Shared over the smaller arm's idea count
+	SameFinal  bool        `json:"same_final"` // both selected a similar final idea
+	Matches    []IdeaMatch `json:"matches,omitempty"`
+}
+
+// IdeaMatch pairs an idea of one arm with the most similar idea of another
+type IdeaMatch struct {
+	A          string  `json:"a"` // idea titles
+	B          string  `json:"b"`
+	Similarity float64 `json:"similarity"`
+}
+
+// Arm returns the result of the arm called name, or nil
+func (r *ExperimentResult) Arm(name string) *ArmResult {
+	for i := range r.Arms {
+		if r.Arms[i].Name == name {
+			return &r.Arms[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MessageError is the message type recording an agent contribution that failed
+const MessageError = "error"
+
+// Causes of a failed agent contribution
+const (
+	FailureTimeout   = "timeout"    // the LLM call timed out
+	FailureAuth      = "auth"       // the API rejected the key
+	FailureRateLimit = "rate_limit" // the API is rate limiting or overloaded
+	FailureParse     = "parse"      // the response could not be decoded
+	FailureAPI       = "api"        // any other error status from the API
+	FailureBudget    = "budget"     // the discussion budget was spent before the call
+	FailureUnknown   = "unknown"
+)
+
+// AgentFailure records an agent contribution that failed
+type AgentFailure struct {
+	Role      string    `json:"role"`
+	Agent     string    `json:"agent"`
+	Phase     string    `json:"phase"`
+	Round     int       `json:"round"`
+	Cause     string    `json:"cause"` // see Failure* constants
+	Error     string    `json:"error"`
+	MessageID string    `json:"message_id,omitempty"` // the error message on the discussion
+	Time      time.Time `json:"time"`
+}
+
+// FailureCounts returns the number of failed contributions per cause
+func (d *Discussion) FailureCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, f := range d.Failures {
+		counts[f.Cause]++
+	}
+	return counts
+}
+
+// FailureSummary renders the failure counts by cause, most frequent first,
+// e.g. "timeout ×2, auth ×1". It returns "" when nothing failed.
+func (d *Discussion) FailureSummary() string {
+	counts := d.FailureCounts()
+	causes := make([]string, 0, len(counts))
+	for cause := range counts {
+		causes = append(causes, cause)
+	}
+	sort.Slice(causes, func(i, j int) bool {
+		if counts[causes[i]] != counts[causes[j]] {
+			return counts[causes[i]] > counts[causes[j]]
+		}
+		return causes[i] < causes[j]
+	})
+	parts := make([]string, len(causes))
+	for i, cause := range causes {
+		parts[i] = fmt.Sprintf("%s ×%d", cause, counts[cause])
+	}
+	return strings.Join(parts, ", ")
+}
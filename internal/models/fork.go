@@ -0,0 +1,256 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+)
+
+// Checkpoint is the state of a discussion at a round boundary, from which
+// it can be forked. Round 0 is the end of the kickoff.
+type Checkpoint struct {
+	Round        int    `json:"round"`
+	MessageCount int    `json:"message_count"` // Messages[:MessageCount] existed at the boundary
+	Ideas        []Idea `json:"ideas"`
+}
+
+// Clone returns a deep copy of the idea
+func (i Idea) Clone() Idea {
+	c := i
+	c.Pros = slices.Clone(i.Pros)
+	c.Cons = slices.Clone(i.Cons)
+	c.CriterionScores = maps.Clone(i.CriterionScores)
+	c.MergedFrom = slices.Clone(i.MergedFrom)
+	c.ParentIDs = slices.Clone(i.ParentIDs)
+	c.Revisions = slices.Clone(i.Revisions)
+	c.JudgeScores = slices.Clone(i.JudgeScores)
+	c.Risks = slices.Clone(i.Risks)
+	c.Plan = i.Plan.Clone()
+	if i.DeepDive != nil {
+		dd := *i.DeepDive
+		dd.Notes = maps.Clone(i.DeepDive.Notes)
+		c.DeepDive = &dd
+	}
+	return c
+}
+
+// Clone returns a deep copy of the discussion. The seed and recalled
+// memories, which never change once set, are shared.
+func (d *Discussion) Clone() *Discussion {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	c.Messages = slices.Clone(d.Messages)
+	c.Ideas = CloneIdeas(d.Ideas)
+	if d.FinalIdea != nil {
+		final := d.FinalIdea.Clone()
+		c.FinalIdea = &final
+	}
+	if d.RoundSummaries != nil {
+		c.RoundSummaries = make([]RoundSummary, len(d.RoundSummaries))
+		for i, rs := range d.RoundSummaries {
+			c.RoundSummaries[i] = rs.Clone()
+		}
+	}
+	c.Convergence = slices.Clone(d.Convergence)
+	if d.Tournament != nil {
+		t := *d.Tournament
+		t.IdeaIDs = slices.Clone(t.IdeaIDs)
+		t.Matches = slices.Clone(t.Matches)
+		t.WinMatrix = make([][]int, len(d.Tournament.WinMatrix))
+		for i, row := range d.Tournament.WinMatrix {
+			t.WinMatrix[i] = slices.Clone(row)
+		}
+		c.Tournament = &t
+	}
+	c.Criteria = slices.Clone(d.Criteria)
+	c.UnscoredIdeas = slices.Clone(d.UnscoredIdeas)
+	c.Budget = d.Budget.Clone()
+	c.Metrics = d.Metrics.Clone()
+	c.Failures = slices.Clone(d.Failures)
+	c.ChildIDs = slices.Clone(d.ChildIDs)
+	if d.Checkpoints != nil {
+		c.Checkpoints = make([]Checkpoint, len(d.Checkpoints))
+		for i, cp := range d.Checkpoints {
+			c.Checkpoints[i] = Checkpoint{Round: cp.Round, MessageCount: cp.MessageCount, Ideas: CloneIdeas(cp.Ideas)}
+		}
+	}
+	c.ForkIDs = slices.Clone(d.ForkIDs)
+	return &c
+}
+
+// CloneIdeas deep-copies a list of ideas
+func CloneIdeas(ideas []Idea) []Idea {
+	if ideas == nil {
+		return nil
+	}
+	out := make([]Idea, len(ideas))
+	for i, idea := range ideas {
+		out[i] = idea.Clone()
+	}
+	return out
+}
+
+// CheckpointRounds lists the rounds a discussion can be forked at
+func (d *Discussion) CheckpointRounds() []int {
+	var rounds []int
+	for _, cp := range d.Checkpoints {
+		rounds = append(rounds, cp.Round)
+	}
+	return rounds
+}
+
+// ForkDiscussion branches src at the end of round (0 = after kickoff). The
+// fork shares src's messages and ideas up to that boundary and is ready to
+// continue from the next round; it has no ID until it runs.
+func ForkDiscussion(src *Discussion, round int) (*Discussion, error) {
+	var cp *Checkpoint
+	for i := range src.Checkpoints {
+		if src.Checkpoints[i].Round == round {
+			cp = &src.Checkpoints[i]
+			break
+		}
+	}
+	if cp == nil {
+		return nil, fmt.Errorf("no checkpoint at round %d (available: %v)", round, src.CheckpointRounds())
+	}
+
+	fork := &Discussion{
+		Topic:     src.Topic,
+		Messages:  slices.Clone(src.Messages[:cp.MessageCount]),
+		Ideas:     CloneIdeas(cp.Ideas),
+		Round:     cp.Round,
+		Criteria:  slices.Clone(src.Criteria),
+		Seed:      src.Seed,
+		Recalled:  src.Recalled,
+		ParentID:  src.ParentID,
+		ForkOf:    src.ID,
+		ForkRound: cp.Round,
+	}
+	if fork.Ideas == nil {
+		fork.Ideas = []Idea{}
+	}
+	for _, rs := range src.RoundSummaries {
+		if rs.Round <= round {
+			fork.RoundSummaries = append(fork.RoundSummaries, rs.Clone())
+		}
+	}
+	for _, sig := range src.Convergence {
+		if sig.Round <= round {
+			fork.Convergence = append(fork.Convergence, sig)
+		}
+	}
+	for _, c := range src.Checkpoints {
+		if c.Round <= round {
+			fork.Checkpoints = append(fork.Checkpoints, Checkpoint{Round: c.Round, MessageCount: c.MessageCount, Ideas: CloneIdeas(c.Ideas)})
+		}
+	}
+	return fork, nil
+}
+
+// SaveDiscussion writes a discussion to path as JSON
+func SaveDiscussion(path string, d *Discussion) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding discussion: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing discussion: %w", err)
+	}
+	return nil
+}
+
+// LoadDiscussion reads a discussion saved by SaveDiscussion
+func LoadDiscussion(path string) (*Discussion, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading discussion: %w", err)
+	}
+	var d Discussion
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("decoding discussion %s: %w", path, err)
+	}
+	return &d, nil
+}
+
+// DiscussionComparison sets two discussions that share history side by side
+type DiscussionComparison struct {
+	A            *ComparisonSide `json:"a"`
+	B            *ComparisonSide `json:"b"`
+	SharedRounds int             `json:"shared_rounds"` // rounds of history both contain
+	SameOutcome  bool            `json:"same_outcome"`  // both selected the same idea
+	OnlyInA      []string        `json:"only_in_a"`     // titles of ideas A has and B does not
+	OnlyInB      []string        `json:"only_in_b"`
+	ScoreChanges []ScoreChange   `json:"score_changes,omitempty"` // shared ideas scored differently
+}
+
+// ComparisonSide is one side of a comparison
+type ComparisonSide struct {
+	ID         string  `json:"id"`
+	Rounds     int     `json:"rounds"`
+	StopReason string  `json:"stop_reason,omitempty"`
+	Ideas      int     `json:"ideas"`
+	FinalIdea  string  `json:"final_idea,omitempty"`
+	FinalScore float64 `json:"final_score,omitempty"`
+}
+
+// ScoreChange is a shared idea's score in each discussion
+type ScoreChange struct {
+	Title  string  `json:"title"`
+	ScoreA float64 `json:"score_a"`
+	ScoreB float64 `json:"score_b"`
+}
+
+// CompareDiscussions compares two discussions, typically a discussion and a
+// fork of it or two forks of the same discussion. Ideas are matched by ID.
+func CompareDiscussions(a, b *Discussion) *DiscussionComparison {
+	c := &DiscussionComparison{A: summarize(a), B: summarize(b)}
+	switch {
+	case b.ForkOf == a.ID:
+		c.SharedRounds = b.ForkRound
+	case a.ForkOf == b.ID:
+		c.SharedRounds = a.ForkRound
+	case a.ForkOf != "" && a.ForkOf == b.ForkOf:
+		c.SharedRounds = min(a.ForkRound, b.ForkRound)
+	}
+	if a.FinalIdea != nil && b.FinalIdea != nil {
+		c.SameOutcome = a.FinalIdea.ID == b.FinalIdea.ID
+	}
+
+	inB := make(map[string]Idea, len(b.Ideas))
+	for _, idea := range b.Ideas {
+		inB[idea.ID] = idea
+	}
+	inA := make(map[string]bool, len(a.Ideas))
+	for _, idea := range a.Ideas {
+		inA[idea.ID] = true
+		other, shared := inB[idea.ID]
+		if !shared {
+			c.OnlyInA = append(c.OnlyInA, idea.Title)
+			continue
+		}
+		if idea.Score != other.Score {
+			c.ScoreChanges = append(c.ScoreChanges, ScoreChange{Title: idea.Title, ScoreA: idea.Score, ScoreB: other.Score})
+		}
+	}
+	for _, idea := range b.Ideas {
+		if !inA[idea.ID] {
+			c.OnlyInB = append(c.OnlyInB, idea.Title)
+		}
+	}
+	sort.Slice(c.ScoreChanges, func(i, j int) bool { return c.ScoreChanges[i].Title < c.ScoreChanges[j].Title })
+	return c
+}
+
+func summarize(d *Discussion) *ComparisonSide {
+	s := &ComparisonSide{ID: d.ID, Rounds: d.Round, StopReason: d.StopReason, Ideas: len(d.Ideas)}
+	if d.FinalIdea != nil {
+		s.FinalIdea = d.FinalIdea.Title
+		s.FinalScore = d.FinalIdea.Score
+	}
+	return s
+}
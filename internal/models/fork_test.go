@@ -0,0 +1,187 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// forkSource is a finished three-round discussion with a checkpoint after
+// the kickoff and after each round
+func forkSource() *Discussion {
+	d := &Discussion{ID: "src", Topic: "food waste", Round: 3, Status: "completed", Criteria: DefaultCriteria()}
+	var ideas []Idea
+	for round := 0; round <= 3; round++ {
+		d.Messages = append(d.Messages, Message{ID: fmt.Sprintf("m%d", round), Content: fmt.Sprintf("round %d", round)})
+		if round > 0 {
+			ideas = append(ideas, Idea{ID: fmt.Sprintf("i%d", round), Title: fmt.Sprintf("Idea %d", round), Round: round, Pros: []string{"cheap"}})
+			d.RoundSummaries = append(d.RoundSummaries, RoundSummary{Round: round})
+			d.Convergence = append(d.Convergence, ConvergenceSignal{Round: round})
+		}
+		d.Checkpoints = append(d.Checkpoints, Checkpoint{Round: round, MessageCount: len(d.Messages), Ideas: CloneIdeas(ideas)})
+	}
+	d.Ideas = CloneIdeas(ideas)
+	d.Ideas[0].Score = 8
+	final := d.Ideas[0].Clone()
+	d.FinalIdea = &final
+	d.Summary = "Go with idea 1"
+	return d
+}
+
+// TestForkDiscussionDropsLaterRounds checks a fork at round N keeps only the
+// messages, ideas, summaries, convergence signals and checkpoints up to N,
+// and none of the source's outcome.
+func TestForkDiscussionDropsLaterRounds(t *testing.T) {
+	for round := 0; round <= 3; round++ {
+		t.Run(fmt.Sprintf("round %d", round), func(t *testing.T) {
+			src := forkSource()
+			fork, err := ForkDiscussion(src, round)
+			if err != nil {
+				t.Fatalf("ForkDiscussion: %v", err)
+			}
+			if fork.ForkOf != "src" || fork.ForkRound != round || fork.Round != round || fork.ID != "" {
+				t.Errorf("fork links = %q round %d (at %d), ID %q", fork.ForkOf, fork.ForkRound, fork.Round, fork.ID)
+			}
+			if len(fork.Messages) != round+1 || fork.Messages[len(fork.Messages)-1].ID != fmt.Sprintf("m%d", round) {
+				t.Errorf("fork has %d messages, want m0..m%d", len(fork.Messages), round)
+			}
+			if len(fork.Ideas) != round || fork.Ideas == nil {
+				t.Errorf("fork has ideas %v, want the %d from rounds 1..%d", fork.Ideas, round, round)
+			}
+			for _, idea := range fork.Ideas {
+				if idea.Round > round || idea.Score != 0 {
+					t.Errorf("fork kept idea %s from round %d with score %.1f", idea.ID, idea.Round, idea.Score)
+				}
+			}
+			if len(fork.RoundSummaries) != round || len(fork.Convergence) != round || len(fork.Checkpoints) != round+1 {
+				t.Errorf("fork has %d summaries, %d signals and %d checkpoints, want %d, %d and %d",
+					len(fork.RoundSummaries), len(fork.Convergence), len(fork.Checkpoints), round, round, round+1)
+			}
+			if fork.FinalIdea != nil || fork.Summary != "" || fork.Status != "" {
+				t.Errorf("fork kept the source's outcome: final %v, summary %q, status %q", fork.FinalIdea, fork.Summary, fork.Status)
+			}
+
+			// The fork's history is its own
+			if len(fork.Ideas) > 0 {
+				fork.Ideas[0].Pros[0] = "changed"
+				fork.Checkpoints[len(fork.Checkpoints)-1].Ideas[0].Title = "changed"
+				if src.Checkpoints[round].Ideas[0].Pros[0] != "cheap" || src.Checkpoints[round].Ideas[0].Title == "changed" {
+					t.Error("changing the fork changed the source's checkpoint")
+				}
+			}
+			fork.Messages[0].Content = "changed"
+			if src.Messages[0].Content != "round 0" {
+				t.Error("changing the fork changed the source's messages")
+			}
+		})
+	}
+
+	if _, err := ForkDiscussion(forkSource(), 7); err == nil {
+		t.Error("ForkDiscussion at a round without a checkpoint succeeded")
+	}
+}
+
+// TestDiscussionCloneSharesNothing fills every field of a discussion and
+// checks its clone shares no slice, map or pointer with it, except the seed
+// and recalled memories Clone documents as shared.
+func TestDiscussionCloneSharesNothing(t *testing.T) {
+	var d Discussion
+	fill(reflect.ValueOf(&d).Elem(), 6)
+	c := d.Clone()
+	shared := map[string]bool{"Discussion.Seed": true, "Discussion.Recalled": true}
+	checkDisjoint(t, reflect.ValueOf(&d).Elem(), reflect.ValueOf(c).Elem(), "Discussion", shared)
+	if !reflect.DeepEqual(&d, c) {
+		t.Error("clone differs from the discussion")
+	}
+}
+
+// fill sets every exported field reachable from v to a non-zero value,
+// giving slices and maps one element, down to depth levels of indirection
+func fill(v reflect.Value, depth int) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	case reflect.Pointer:
+		if depth > 0 {
+			v.Set(reflect.New(v.Type().Elem()))
+			fill(v.Elem(), depth-1)
+		}
+	case reflect.Slice:
+		if depth > 0 {
+			s := reflect.MakeSlice(v.Type(), 1, 1)
+			fill(s.Index(0), depth-1)
+			v.Set(s)
+		}
+	case reflect.Map:
+		if depth > 0 {
+			m := reflect.MakeMap(v.Type())
+			key, elem := reflect.New(v.Type().Key()).Elem(), reflect.New(v.Type().Elem()).Elem()
+			fill(key, depth-1)
+			fill(elem, depth-1)
+			m.SetMapIndex(key, elem)
+			v.Set(m)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				fill(v.Field(i), depth)
+			}
+		}
+	}
+}
+
+// checkDisjoint reports every slice, map or pointer a and b share, other
+// than the paths in shared
+func checkDisjoint(t *testing.T, a, b reflect.Value, path string, shared map[string]bool) {
+	t.Helper()
+	if shared[path] {
+		return
+	}
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			t.Errorf("%s is shared", path)
+			return
+		}
+		checkDisjoint(t, a.Elem(), b.Elem(), path, shared)
+	case reflect.Slice:
+		if a.Len() == 0 || b.Len() == 0 {
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			t.Errorf("%s is shared", path)
+			return
+		}
+		for i := 0; i < a.Len() && i < b.Len(); i++ {
+			checkDisjoint(t, a.Index(i), b.Index(i), fmt.Sprintf("%s[%d]", path, i), shared)
+		}
+	case reflect.Map:
+		if a.Len() == 0 || b.Len() == 0 {
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			t.Errorf("%s is shared", path)
+			return
+		}
+		for _, key := range a.MapKeys() {
+			if bv := b.MapIndex(key); bv.IsValid() {
+				checkDisjoint(t, a.MapIndex(key), bv, fmt.Sprintf("%s[%v]", path, key), shared)
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if f := a.Type().Field(i); f.IsExported() {
+				checkDisjoint(t, a.Field(i), b.Field(i), path+"."+f.Name, shared)
+			}
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	maxRememberedRejections = 8
+	maxRememberedLessons    = 5
+)
+
+// MemoryEntry is what the team remembers from one finished discussion
+type MemoryEntry struct {
+	DiscussionID string           `json:"discussion_id"`
+	Topic        string           `json:"topic"`
+	Date         time.Time        `json:"date"`
+	FinalIdea    *RememberedIdea  `json:"final_idea,omitempty"`
+	Rejected     []RememberedIdea `json:"rejected,omitempty"` // ideas the team considered and did not pick
+	Lessons      []string         `json:"lessons,omitempty"`  // key insights from the leader's round summaries
+	Summary      string           `json:"summary,omitempty"`
+
+	// Vector is the embedding of the entry's text, when the backend supports embeddings
+	Vector []float64 `json:"vector,omitempty"`
+}
+
+// RememberedIdea is an idea recorded in team memory, with why it was or wasn't picked
+type RememberedIdea struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description,omitempty"`
+	Score       float64 `json:"score,omitempty"`
+	Reason      string  `json:"reason,omitempty"`
+}
+
+// TeamMemory is the persistent store of past discussions
+type TeamMemory struct {
+	Entries []MemoryEntry `json:"entries"`
+}
+
+// DefaultMemoryPath returns the team memory file: $AI_AGENT_TEAM_MEMORY, or
+// .ai-agent-team/memory.json in the user's home directory
+func DefaultMemoryPath() string {
+	if p := os.Getenv("AI_AGENT_TEAM_MEMORY"); p != "" {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".ai-agent-team", "memory.json")
+	}
+	return filepath.Join(home, ".ai-agent-team", "memory.json")
+}
+
+// LoadTeamMemory reads the team memory at path. A missing file is an empty memory.
+func LoadTeamMemory(path string) (*TeamMemory, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &TeamMemory{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading team memory: %w", err)
+	}
+	var m TeamMemory
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("decoding team memory %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Save writes the team memory to path, creating its directory if needed
+func (m *TeamMemory) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding team memory: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("creating team memory directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing team memory: %w", err)
+	}
+	return nil
+}
+
+// Remember adds an entry, replacing any earlier entry for the same discussion
+func (m *TeamMemory) Remember(e MemoryEntry) {
+	for i := range m.Entries {
+		if m.Entries[i].DiscussionID == e.DiscussionID {
+			m.Entries[i] = e
+			return
+		}
+	}
+	m.Entries = append(m.Entries, e)
+}
+
+// NewMemoryEntry distills a finished discussion into a memory entry: the
+// final idea, the ideas not picked and why, and the leader's key insights
+func NewMemoryEntry(d *Discussion) MemoryEntry {
+	e := MemoryEntry{
+		DiscussionID: d.ID,
+		Topic:        d.Topic,
+		Date:         d.EndTime,
+		Summary:      d.Summary,
+	}
+	if e.Date.IsZero() {
+		e.Date = time.Now()
+	}
+	if f := d.FinalIdea; f != nil {
+		e.FinalIdea = &RememberedIdea{Title: f.Title, Description: f.Description, Score: f.Score, Reason: "selected"}
+	}
+
+	dropped := d.DroppedIdeas()
+	for _, idea := range d.Ideas {
+		if d.FinalIdea != nil && idea.ID == d.FinalIdea.ID {
+			continue
+		}
+		if len(e.Rejected) == maxRememberedRejections {
+			break
+		}
+		r := RememberedIdea{Title: idea.Title, Description: idea.Description, Score: idea.Score, Reason: "not selected"}
+		switch {
+		case dropped[idea.ID]:
+			r.Reason = "set aside by the team leader"
+		case idea.Validated && len(idea.Cons) > 0:
+			r.Reason = fmt.Sprintf("scored %.1f/10; %s", idea.Score, idea.Cons[0])
+		case idea.Validated:
+			r.Reason = fmt.Sprintf("scored %.1f/10", idea.Score)
+		}
+		e.Rejected = append(e.Rejected, r)
+	}
+
+	for _, s := range d.RoundSummaries {
+		for _, insight := range s.KeyInsights {
+			if len(e.Lessons) < maxRememberedLessons {
+				e.Lessons = append(e.Lessons, insight)
+			}
+		}
+	}
+	return e
+}
+
+// Text is the text used to match the entry against a new topic
+func (e MemoryEntry) Text() string {
+	parts := []string{e.Topic}
+	if e.FinalIdea != nil {
+		parts = append(parts, e.FinalIdea.Title, e.FinalIdea.Description)
+	}
+	for _, r := range e.Rejected {
+		parts = append(parts, r.Title)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// MemoryContext describes the past discussions recalled for this one, for
+// the agents that build on earlier decisions. It returns "" when none were recalled.
+func (d *Discussion) MemoryContext() string {
+	if d == nil || len(d.Recalled) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("Team memory — related past discussions:\n")
+	for _, e := range d.Recalled {
+		fmt.Fprintf(&b, "- %q (%s)\n", e.Topic, e.Date.Format("2006-01-02"))
+		if e.FinalIdea != nil {
+			fmt.Fprintf(&b, "  Chose: %s - %s\n", e.FinalIdea.Title, excerpt(e.FinalIdea.Description, 200))
+		}
+		for _, r := range e.Rejected {
+			fmt.Fprintf(&b, "  Rejected: %s (%s)\n", r.Title, r.Reason)
+		}
+		for _, l := range e.Lessons {
+			fmt.Fprintf(&b, "  Lesson: %s\n", l)
+		}
+	}
+	b.WriteString("Build on these earlier decisions. Do not re-propose a rejected idea unless you say what has changed.\n\n")
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Outcomes of a phase or LLM call
+const (
+	OutcomeOK     = "ok"
+	OutcomeError  = "error"
+	OutcomeFailed = "failed" // the discussion stopped in this phase
+)
+
+// RunMetrics records the timing and estimated spend of every phase and LLM
+// call in a discussion. Token counts and costs are estimates.
+type RunMetrics struct {
+	Phases []PhaseMetrics `json:"phases"`
+	Calls  []CallMetrics  `json:"calls"`
+}
+
+// PhaseMetrics is one run of a phase; exploration and synthesis repeat each round
+type PhaseMetrics struct {
+	Phase    string        `json:"phase"`
+	Label    string        `json:"label"`
+	Round    int           `json:"round,omitempty"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Calls    int           `json:"calls"`
+	Tokens   int           `json:"tokens"`
+	Cost     float64       `json:"cost"` // estimated USD
+	Errors   int           `json:"errors"`
+	Outcome  string        `json:"outcome"`
+}
+
+// CallMetrics is one LLM request made by an agent
+type CallMetrics struct {
+	Phase        string        `json:"phase"`
+	Round        int           `json:"round,omitempty"`
+	Role         string        `json:"role"`
+	Model        string        `json:"model"`
+	Start        time.Time     `json:"start"`
+	Duration     time.Duration `json:"duration"`
+	InputTokens  int           `json:"input_tokens"`
+	OutputTokens int           `json:"output_tokens"`
+	Cost         float64       `json:"cost"`    // estimated USD
+	Retries      int           `json:"retries"` // earlier attempts at the same request
+	Outcome      string        `json:"outcome"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// RoleMetrics totals the calls of one agent role
+type RoleMetrics struct {
+	Role     string        `json:"role"`
+	Model    string        `json:"model"` // model of the role's latest call
+	Calls    int           `json:"calls"`
+	Duration time.Duration `json:"duration"`
+	Tokens   int           `json:"tokens"`
+	Cost     float64       `json:"cost"`
+	Retries  int           `json:"retries"`
+	Errors   int           `json:"errors"`
+}
+
+// ByRole totals the calls per agent role, slowest role first
+func (m *RunMetrics) ByRole() []RoleMetrics {
+	if m == nil {
+		return nil
+	}
+	index := make(map[string]int)
+	var roles []RoleMetrics
+	for _, c := range m.Calls {
+		i, ok := index[c.Role]
+		if !ok {
+			i = len(roles)
+			index[c.Role] = i
+			roles = append(roles, RoleMetrics{Role: c.Role})
+		}
+		r := &roles[i]
+		r.Model = c.Model
+		r.Calls++
+		r.Duration += c.Duration
+		r.Tokens += c.InputTokens + c.OutputTokens
+		r.Cost += c.Cost
+		r.Retries += c.Retries
+		if c.Outcome != OutcomeOK {
+			r.Errors++
+		}
+	}
+	sort.SliceStable(roles, func(i, j int) bool { return roles[i].Duration > roles[j].Duration })
+	return roles
+}
+
+// Clone copies the metrics so they can be read while the discussion runs
+func (m *RunMetrics) Clone() *RunMetrics {
+	if m == nil {
+		return nil
+	}
+	return &RunMetrics{
+		Phases: append([]PhaseMetrics(nil), m.Phases...),
+		Calls:  append([]CallMetrics(nil), m.Calls...),
+	}
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Model assignment modes
+const (
+	ModelAssignPolicy = "policy" // pick models by ModelPolicy rules (default)
+	ModelAssignLLM    = "llm"    // the team leader picks models from the available list
+)
+
+// ModelPolicy holds the rules for policy-based model assignment. Patterns are
+// exact model IDs or globs, e.g. "gpt-4o-mini" or "claude-sonnet-*".
+type ModelPolicy struct {
+	Prefer       map[AgentRole][]string `json:"prefer,omitempty"`         // per role, most preferred first
+	Allow        []string               `json:"allow,omitempty"`          // only models matching one of these (empty = any)
+	Deny         []string               `json:"deny,omitempty"`           // never models matching these
+	MaxPrice     float64                `json:"max_price,omitempty"`      // ceiling on output price, USD per million tokens (0 = none)
+	RoleMaxPrice map[AgentRole]float64  `json:"role_max_price,omitempty"` // per-role ceilings overriding MaxPrice
+}
+
+// PriceCeiling returns the output price ceiling for role, or 0 for none
+func (p ModelPolicy) PriceCeiling(role AgentRole) float64 {
+	if c, ok := p.RoleMaxPrice[role]; ok && c > 0 {
+		return c
+	}
+	return p.MaxPrice
+}
+
+// ModelPolicyFromEnv reads a model policy from comma-separated
+// <prefix>_ALLOW and <prefix>_DENY, <prefix>_MAX_PRICE, and per role
+// <prefix>_PREFER_<ROLE> and <prefix>_MAX_PRICE_<ROLE>, e.g.
+// MODEL_POLICY_PREFER_RESEARCHER=gpt-4o,claude-sonnet-*. Invalid prices are ignored.
+func ModelPolicyFromEnv(prefix string) ModelPolicy {
+	list := func(v string) []string {
+		var out []string
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	price := func(v string) float64 {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 {
+			return 0
+		}
+		return f
+	}
+
+	p := ModelPolicy{
+		Allow:    list(os.Getenv(prefix + "_ALLOW")),
+		Deny:     list(os.Getenv(prefix + "_DENY")),
+		MaxPrice: price(os.Getenv(prefix + "_MAX_PRICE")),
+	}
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		if role, ok := strings.CutPrefix(name, prefix+"_PREFER_"); ok && role != "" {
+			if p.Prefer == nil {
+				p.Prefer = make(map[AgentRole][]string)
+			}
+			p.Prefer[AgentRole(strings.ToLower(role))] = list(value)
+		}
+		if role, ok := strings.CutPrefix(name, prefix+"_MAX_PRICE_"); ok && role != "" {
+			if c := price(value); c > 0 {
+				if p.RoleMaxPrice == nil {
+					p.RoleMaxPrice = make(map[AgentRole]float64)
+				}
+				p.RoleMaxPrice[AgentRole(strings.ToLower(role))] = c
+			}
+		}
+	}
+	return p
+}
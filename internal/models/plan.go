@@ -0,0 +1,251 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ImplementationPlan is the implementer's structured plan for building an idea
+type ImplementationPlan struct {
+	Summary        string          `json:"summary,omitempty"`
+	Phases         []PlanPhase     `json:"phases"`
+	Roles          []string        `json:"roles,omitempty"` // people or skills the plan needs
+	SuccessMetrics []SuccessMetric `json:"success_metrics,omitempty"`
+	CreatedBy      string          `json:"created_by,omitempty"`
+	Round          int             `json:"round,omitempty"`
+}
+
+// PlanPhase is one phase of a plan, ending in a milestone
+type PlanPhase struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Milestone     string     `json:"milestone,omitempty"`
+	DurationWeeks float64    `json:"duration_weeks"`
+	DependsOn     []string   `json:"depends_on,omitempty"` // IDs of phases that must finish first
+	Tasks         []PlanTask `json:"tasks"`
+
+	// StartWeek is computed by Schedule from the phase dependencies
+	StartWeek float64 `json:"start_week"`
+}
+
+// PlanTask is one unit of work within a phase
+type PlanTask struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	EffortDays  float64  `json:"effort_days"`
+	Role        string   `json:"role,omitempty"`
+	DependsOn   []string `json:"depends_on,omitempty"` // IDs of tasks that must finish first
+}
+
+// SuccessMetric is a measurable outcome that shows the plan worked
+type SuccessMetric struct {
+	Name   string `json:"name"`
+	Target string `json:"target"`
+}
+
+// IdeaPlan is an implementation plan an agent proposed for a specific idea
+type IdeaPlan struct {
+	IdeaID string              `json:"idea_id"`
+	Plan   *ImplementationPlan `json:"plan"`
+}
+
+// Validate checks the plan against its schema: at least one phase, every
+// phase and task named with a unique ID, positive durations, non-negative
+// effort, and dependencies that refer to known phases or tasks without
+// cycles. It reports every problem found.
+func (p *ImplementationPlan) Validate() error {
+	if p == nil {
+		return errors.New("plan is missing")
+	}
+	var errs []error
+	if len(p.Phases) == 0 {
+		errs = append(errs, errors.New("plan has no phases"))
+	}
+
+	phases := make(map[string]bool)
+	tasks := make(map[string]bool)
+	for i, ph := range p.Phases {
+		where := fmt.Sprintf("phase %d", i+1)
+		switch {
+		case ph.ID == "":
+			errs = append(errs, fmt.Errorf("%s: missing id", where))
+		case phases[ph.ID]:
+			errs = append(errs, fmt.Errorf("%s: duplicate id %q", where, ph.ID))
+		}
+		phases[ph.ID] = true
+		if strings.TrimSpace(ph.Name) == "" {
+			errs = append(errs, fmt.Errorf("%s: missing name", where))
+		}
+		if ph.DurationWeeks <= 0 {
+			errs = append(errs, fmt.Errorf("%s: duration_weeks must be positive", where))
+		}
+		if len(ph.Tasks) == 0 {
+			errs = append(errs, fmt.Errorf("%s: no tasks", where))
+		}
+		for j, t := range ph.Tasks {
+			where := fmt.Sprintf("phase %d task %d", i+1, j+1)
+			switch {
+			case t.ID == "":
+				errs = append(errs, fmt.Errorf("%s: missing id", where))
+			case tasks[t.ID]:
+				errs = append(errs, fmt.Errorf("%s: duplicate id %q", where, t.ID))
+			}
+			tasks[t.ID] = true
+			if strings.TrimSpace(t.Title) == "" {
+				errs = append(errs, fmt.Errorf("%s: missing title", where))
+			}
+			if t.EffortDays < 0 {
+				errs = append(errs, fmt.Errorf("%s: effort_days must not be negative", where))
+			}
+		}
+	}
+
+	for _, ph := range p.Phases {
+		for _, dep := range ph.DependsOn {
+			if !phases[dep] {
+				errs = append(errs, fmt.Errorf("phase %q depends on unknown phase %q", ph.ID, dep))
+			}
+		}
+		for _, t := range ph.Tasks {
+			for _, dep := range t.DependsOn {
+				if !tasks[dep] {
+					errs = append(errs, fmt.Errorf("task %q depends on unknown task %q", t.ID, dep))
+				}
+			}
+		}
+	}
+	if len(errs) == 0 {
+		if id := p.phaseCycle(); id != "" {
+			errs = append(errs, fmt.Errorf("phase %q is part of a dependency cycle", id))
+		}
+		if id := p.taskCycle(); id != "" {
+			errs = append(errs, fmt.Errorf("task %q is part of a dependency cycle", id))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// phaseCycle returns the ID of a phase on a dependency cycle, or ""
+func (p *ImplementationPlan) phaseCycle() string {
+	deps := make(map[string][]string)
+	for _, ph := range p.Phases {
+		deps[ph.ID] = ph.DependsOn
+	}
+	return findCycle(deps)
+}
+
+// taskCycle returns the ID of a task on a dependency cycle, or ""
+func (p *ImplementationPlan) taskCycle() string {
+	deps := make(map[string][]string)
+	for _, t := range p.Tasks() {
+		deps[t.ID] = t.DependsOn
+	}
+	return findCycle(deps)
+}
+
+// findCycle returns a node on a cycle in the dependency graph, or ""
+func findCycle(deps map[string][]string) string {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var visit func(id string) string
+	visit = func(id string) string {
+		switch state[id] {
+		case visiting:
+			return id
+		case done:
+			return ""
+		}
+		state[id] = visiting
+		for _, dep := range deps[id] {
+			if c := visit(dep); c != "" {
+				return c
+			}
+		}
+		state[id] = done
+		return ""
+	}
+	for id := range deps {
+		if c := visit(id); c != "" {
+			return c
+		}
+	}
+	return ""
+}
+
+// Schedule sets each phase's StartWeek to the latest finish of the phases it
+// depends on. Phases without dependencies start at week 0. The plan must be valid.
+func (p *ImplementationPlan) Schedule() {
+	index := make(map[string]int)
+	for i, ph := range p.Phases {
+		index[ph.ID] = i
+	}
+	scheduled := make(map[string]bool)
+	var schedule func(i int) float64
+	schedule = func(i int) float64 {
+		ph := &p.Phases[i]
+		if !scheduled[ph.ID] {
+			scheduled[ph.ID] = true
+			start := 0.0
+			for _, dep := range ph.DependsOn {
+				start = max(start, schedule(index[dep]))
+			}
+			ph.StartWeek = start
+		}
+		return ph.StartWeek + ph.DurationWeeks
+	}
+	for i := range p.Phases {
+		schedule(i)
+	}
+}
+
+// Tasks returns every task in the plan, in phase order
+func (p *ImplementationPlan) Tasks() []PlanTask {
+	var tasks []PlanTask
+	for _, ph := range p.Phases {
+		tasks = append(tasks, ph.Tasks...)
+	}
+	return tasks
+}
+
+// TotalWeeks is the scheduled length of the plan
+func (p *ImplementationPlan) TotalWeeks() float64 {
+	total := 0.0
+	for _, ph := range p.Phases {
+		total = max(total, ph.StartWeek+ph.DurationWeeks)
+	}
+	return total
+}
+
+// TotalEffortDays sums the effort of every task
+func (p *ImplementationPlan) TotalEffortDays() float64 {
+	total := 0.0
+	for _, t := range p.Tasks() {
+		total += t.EffortDays
+	}
+	return total
+}
+
+// Clone deep-copies the plan
+func (p *ImplementationPlan) Clone() *ImplementationPlan {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	c.Roles = append([]string(nil), p.Roles...)
+	c.SuccessMetrics = append([]SuccessMetric(nil), p.SuccessMetrics...)
+	c.Phases = make([]PlanPhase, len(p.Phases))
+	for i, ph := range p.Phases {
+		ph.DependsOn = append([]string(nil), ph.DependsOn...)
+		ph.Tasks = append([]PlanTask(nil), ph.Tasks...)
+		for j := range ph.Tasks {
+			ph.Tasks[j].DependsOn = append([]string(nil), ph.Tasks[j].DependsOn...)
+		}
+		c.Phases[i] = ph
+	}
+	return &c
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+// validPlan returns a two-phase plan where the second phase and its task
+// depend on the first
+func validPlan() *ImplementationPlan {
+	return &ImplementationPlan{Phases: []PlanPhase{
+		{ID: "p1", Name: "Pilot", DurationWeeks: 2, Tasks: []PlanTask{
+			{ID: "t1", Title: "Recruit hosts", EffortDays: 3},
+			{ID: "t2", Title: "Install fridges", EffortDays: 2, DependsOn: []string{"t1"}},
+		}},
+		{ID: "p2", Name: "Rollout", DurationWeeks: 4, DependsOn: []string{"p1"}, Tasks: []PlanTask{
+			{ID: "t3", Title: "Open more sites", EffortDays: 10, DependsOn: []string{"t2"}},
+		}},
+	}}
+}
+
+// TestPlanValidate checks each schema rule is reported, and that cycles are
+// found across phases and across tasks in different phases.
+func TestPlanValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		edit  func(p *ImplementationPlan)
+		wants []string // substrings of the error; none means the plan is valid
+	}{
+		{"valid", func(p *ImplementationPlan) {}, nil},
+		{"no phases", func(p *ImplementationPlan) { p.Phases = nil }, []string{"plan has no phases"}},
+		{"duplicate phase id", func(p *ImplementationPlan) { p.Phases[1].ID = "p1" }, []string{`phase 2: duplicate id "p1"`}},
+		{"duplicate task id", func(p *ImplementationPlan) { p.Phases[1].Tasks[0].ID = "t1" }, []string{`phase 2 task 1: duplicate id "t1"`}},
+		{"missing ids", func(p *ImplementationPlan) { p.Phases[0].ID, p.Phases[0].Tasks[1].ID = "", "" }, []string{"phase 1: missing id", "phase 1 task 2: missing id"}},
+		{"missing name and title", func(p *ImplementationPlan) { p.Phases[1].Name, p.Phases[1].Tasks[0].Title = " ", "" }, []string{"phase 2: missing name", "phase 2 task 1: missing title"}},
+		{"bad numbers", func(p *ImplementationPlan) { p.Phases[0].DurationWeeks, p.Phases[0].Tasks[0].EffortDays = 0, -1 }, []string{"phase 1: duration_weeks must be positive", "phase 1 task 1: effort_days must not be negative"}},
+		{"empty phase", func(p *ImplementationPlan) { p.Phases[1].Tasks = nil }, []string{"phase 2: no tasks"}},
+		{"dangling phase dependency", func(p *ImplementationPlan) { p.Phases[1].DependsOn = []string{"p9"} }, []string{`phase "p2" depends on unknown phase "p9"`}},
+		{"dangling task dependency", func(p *ImplementationPlan) { p.Phases[1].Tasks[0].DependsOn = []string{"p1"} }, []string{`task "t3" depends on unknown task "p1"`}},
+		{"phase depends on itself", func(p *ImplementationPlan) { p.Phases[0].DependsOn = []string{"p1"} }, []string{`phase "p1" is part of a dependency cycle`}},
+		{"phase cycle", func(p *ImplementationPlan) { p.Phases[0].DependsOn = []string{"p2"} }, []string{`phase "p`, "is part of a dependency cycle"}},
+		{"task cycle across phases", func(p *ImplementationPlan) { p.Phases[0].Tasks[0].DependsOn = []string{"t3"} }, []string{`task "t`, "is part of a dependency cycle"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := validPlan()
+			tt.edit(p)
+			err := p.Validate()
+			if len(tt.wants) == 0 {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() = nil, want %q", tt.wants)
+			}
+			for _, want := range tt.wants {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() = %v, want it to mention %q", err, want)
+				}
+			}
+		})
+	}
+
+	var missing *ImplementationPlan
+	if err := missing.Validate(); err == nil {
+		t.Error("Validate on a nil plan = nil, want an error")
+	}
+}
+
+// TestPlanSchedule checks phases start when their latest dependency finishes
+func TestPlanSchedule(t *testing.T) {
+	p := validPlan()
+	p.Phases = append(p.Phases, PlanPhase{ID: "p3", Name: "Review", DurationWeeks: 1, DependsOn: []string{"p1", "p2"},
+		Tasks: []PlanTask{{ID: "t4", Title: "Survey hosts", EffortDays: 1}}})
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	p.Schedule()
+	for i, want := range []float64{0, 2, 6} {
+		if got := p.Phases[i].StartWeek; got != want {
+			t.Errorf("phase %s starts at week %v, want %v", p.Phases[i].ID, got, want)
+		}
+	}
+	if got := p.TotalWeeks(); got != 7 {
+		t.Errorf("TotalWeeks = %v, want 7", got)
+	}
+}
@@ -0,0 +1,33 @@
+package models
+
+import "regexp"
+
+// Message types for directed questions between agents
+const (
+	MessageQuestion = "question" // addressed to one role, ReplyTo the contribution that raised it
+	MessageResponse = "response" // the addressee's answer, ReplyTo the question
+)
+
+// DirectedQuestionPattern matches a directed question line, "QUESTION @critic:
+// ...", capturing the addressee and the question. The addressee follows the
+// role ID grammar (letters, digits and underscores, matched case-insensitively);
+// Markdown emphasis and list markers around the keyword are tolerated.
+var DirectedQuestionPattern = regexp.MustCompile(`(?m)^[\s>*-]*QUESTION\s+@((?i:[a-z0-9_]+))[*\s]*:[*\s]*(.+?)\s*$`)
+
+// UnansweredQuestions returns the directed questions no agent has replied to,
+// in the order they were asked
+func (d *Discussion) UnansweredQuestions() []Message {
+	answered := make(map[string]bool)
+	for _, m := range d.Messages {
+		if m.Type == MessageResponse && m.ReplyTo != "" {
+			answered[m.ReplyTo] = true
+		}
+	}
+	var open []Message
+	for _, m := range d.Messages {
+		if m.Type == MessageQuestion && !answered[m.ID] {
+			open = append(open, m)
+		}
+	}
+	return open
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// Risk levels used for a risk's severity and likelihood
+const (
+	RiskLow    = "low"
+	RiskMedium = "medium"
+	RiskHigh   = "high"
+)
+
+// Risk is one entry in an idea's risk register, raised by the critic
+type Risk struct {
+	Category     string `json:"category"` // e.g. "market", "technical", "legal"
+	Description  string `json:"description"`
+	Severity     string `json:"severity"`   // RiskLow, RiskMedium or RiskHigh
+	Likelihood   string `json:"likelihood"` // RiskLow, RiskMedium or RiskHigh
+	Mitigation   string `json:"mitigation,omitempty"`
+	OpenQuestion string `json:"open_question,omitempty"`
+	RaisedBy     string `json:"raised_by"`
+	Round        int    `json:"round,omitempty"`
+	Phase        string `json:"phase,omitempty"` // "exploration" or "deep_dive"
+}
+
+// IdeaRisk is a risk an agent raised against a specific idea
+type IdeaRisk struct {
+	IdeaID string `json:"idea_id"`
+	Risk
+}
+
+// NormalizeRiskLevel maps a free-form level to RiskLow, RiskMedium or RiskHigh,
+// defaulting to RiskMedium
+func NormalizeRiskLevel(level string) string {
+	switch l := strings.ToLower(strings.TrimSpace(level)); {
+	case strings.HasPrefix(l, "crit"), strings.HasPrefix(l, "high"), strings.HasPrefix(l, "severe"), l == "likely", l == "very likely":
+		return RiskHigh
+	case strings.HasPrefix(l, "low"), strings.HasPrefix(l, "minor"), l == "unlikely", l == "rare":
+		return RiskLow
+	default:
+		return RiskMedium
+	}
+}
+
+// riskWeight maps a risk level to 1-3
+func riskWeight(level string) int {
+	switch level {
+	case RiskHigh:
+		return 3
+	case RiskLow:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Rating is severity × likelihood on a 1-9 scale
+func (r Risk) Rating() int {
+	return riskWeight(r.Severity) * riskWeight(r.Likelihood)
+}
+
+// SortRisks orders risks by rating, highest first, keeping the order in which
+// they were raised among equals
+func SortRisks(risks []Risk) []Risk {
+	sorted := append([]Risk(nil), risks...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Rating() > sorted[j].Rating() })
+	return sorted
+}
+
+// SameRisk reports whether two risks describe the same concern, so repeated
+// critique does not grow the register with duplicates
+func SameRisk(a, b Risk) bool {
+	return strings.EqualFold(a.Category, b.Category) &&
+		strings.EqualFold(strings.TrimSpace(a.Description), strings.TrimSpace(b.Description))
+}
@@ -0,0 +1,41 @@
+package models
+
+import "slices"
+
+// RoundSummary is the team leader's structured synthesis of one exploration round
+type RoundSummary struct {
+	Round        int      `json:"round"`
+	KeyInsights  []string `json:"key_insights,omitempty"`
+	IdeasAdded   []string `json:"ideas_added,omitempty"`   // IDs of ideas first proposed this round
+	IdeasDropped []string `json:"ideas_dropped,omitempty"` // IDs of ideas the leader set aside
+	Focus        string   `json:"focus,omitempty"`         // what the next round should concentrate on
+	MessageID    string   `json:"message_id,omitempty"`    // the synthesis message it was taken from
+}
+
+// Clone returns a deep copy of the summary
+func (rs RoundSummary) Clone() RoundSummary {
+	c := rs
+	c.KeyInsights = slices.Clone(rs.KeyInsights)
+	c.IdeasAdded = slices.Clone(rs.IdeasAdded)
+	c.IdeasDropped = slices.Clone(rs.IdeasDropped)
+	return c
+}
+
+// LatestRoundSummary returns the summary of the most recent round, or nil
+func (d *Discussion) LatestRoundSummary() *RoundSummary {
+	if len(d.RoundSummaries) == 0 {
+		return nil
+	}
+	return &d.RoundSummaries[len(d.RoundSummaries)-1]
+}
+
+// DroppedIdeas returns the IDs of ideas the leader set aside in any round
+func (d *Discussion) DroppedIdeas() map[string]bool {
+	dropped := make(map[string]bool)
+	for _, s := range d.RoundSummaries {
+		for _, id := range s.IdeasDropped {
+			dropped[id] = true
+		}
+	}
+	return dropped
+}
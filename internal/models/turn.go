@@ -0,0 +1,18 @@
+package models
+
+// TurnDecision is the team leader's choice of who speaks next in a
+// leader-directed exploration round
+type TurnDecision struct {
+	Role   AgentRole `json:"next"`             // the agent to speak next, empty when Done
+	Prompt string    `json:"prompt,omitempty"` // task for that agent (empty = its default task)
+	Reason string    `json:"reason,omitempty"`
+	Done   bool      `json:"-"` // the round has covered enough ground
+}
+
+// TurnCandidate is an agent the leader may hand the floor to, with its
+// default task for the round
+type TurnCandidate struct {
+	Role   AgentRole
+	Name   string
+	Prompt string
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Message represents a communication between agents
 type Message struct {
@@ -9,12 +12,14 @@ type Message struct {
 	To        string    `json:"to"`
 	Content   string    `json:"content"`
 	Timestamp time.Time `json:"timestamp"`
-	Type      string    `json:"type"` // "idea", "validation", "question", "response", "summary"
+	Type      string    `json:"type"`               // "idea", "validation", "question", "response", "summary"
+	ReplyTo   string    `json:"reply_to,omitempty"` // ID of the message this one answers or follows up
 }
 
 // Idea represents a generated idea
 type Idea struct {
 	ID          string   `json:"id"`
+	Handle      string   `json:"handle,omitempty"` // short stable reference shown to agents, e.g. "I3"
 	Title       string   `json:"title"`
 	Description string   `json:"description"`
 	Pros        []string `json:"pros"`
@@ -23,6 +28,122 @@ type Idea struct {
 	CreatedBy   string   `json:"created_by"`
 	Validated   bool     `json:"validated"`
 	Score       float64  `json:"score"` // validation score 0-10
+
+	// CriterionScores holds the 0-10 score per evaluation criterion; Score is
+	// their weighted mean when present
+	CriterionScores map[string]float64 `json:"criterion_scores,omitempty"`
+
+	// Risks is the risk register the critic built for this idea
+	Risks []Risk `json:"risks,omitempty"`
+
+	// Plan is the implementer's validated implementation plan for this idea
+	Plan *ImplementationPlan `json:"plan,omitempty"`
+
+	// DeepDive holds the focused sub-discussion results when deep dive mode ran on this idea
+	DeepDive *DeepDiveResult `json:"deep_dive,omitempty"`
+
+	// MergedFrom records near-duplicate ideas that were folded into this one
+	MergedFrom []IdeaProvenance `json:"merged_from,omitempty"`
+
+	// Round is the discussion round in which the idea was proposed
+	Round int `json:"round,omitempty"`
+
+	// ParentIDs are the existing ideas this one builds on or refines; the
+	// first entry is the primary parent whose history Revisions continues
+	ParentIDs []string `json:"parent_ids,omitempty"`
+
+	// Revisions is the version history of the idea along its primary lineage,
+	// oldest first, ending with the immediate parent
+	Revisions []IdeaRevision `json:"revisions,omitempty"`
+
+	// Rating is the Elo-scale rating from the pairwise tournament (0 if none ran)
+	Rating float64 `json:"rating,omitempty"`
+	Wins   int     `json:"wins,omitempty"`
+	Losses int     `json:"losses,omitempty"`
+
+	// JudgeScores holds each panel judge's score when a judging panel ran;
+	// Score is then their aggregate
+	JudgeScores   []JudgeScore `json:"judge_scores,omitempty"`
+	ScoreVariance float64      `json:"score_variance,omitempty"` // variance of JudgeScores
+	Disputed      bool         `json:"disputed,omitempty"`       // judges disagreed strongly
+}
+
+// JudgeScore is one panel judge's score for an idea
+type JudgeScore struct {
+	Judge string  `json:"judge"`
+	Model string  `json:"model"`
+	Score float64 `json:"score"`
+}
+
+// IdeaRevision is an earlier version of an idea in its lineage
+type IdeaRevision struct {
+	IdeaID      string  `json:"idea_id"`
+	Round       int     `json:"round"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	CreatedBy   string  `json:"created_by"`
+	Score       float64 `json:"score,omitempty"`
+}
+
+// ShortID returns the abbreviated idea ID agents use to reference ideas
+func (i Idea) ShortID() string {
+	if len(i.ID) > 8 {
+		return i.ID[:8]
+	}
+	return i.ID
+}
+
+// ClearEvaluation drops the idea's moderator evaluation: validation, scores,
+// pros and cons, and any judging panel results
+func (i *Idea) ClearEvaluation() {
+	i.Validated = false
+	i.Score = 0
+	i.CriterionScores = nil
+	i.Pros, i.Cons = nil, nil
+	i.JudgeScores = nil
+	i.ScoreVariance = 0
+	i.Disputed = false
+}
+
+// IdeaProvenance identifies a duplicate idea that was merged into another
+type IdeaProvenance struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	CreatedBy   string  `json:"created_by"`
+	Similarity  float64 `json:"similarity"` // 0-1, as measured by the dedup method
+	Method      string  `json:"method"`     // "embedding" or "lexical"
+}
+
+// DeepDiveResult captures what the researcher, critic and implementer found
+// when examining a single idea in a focused mini-round
+type DeepDiveResult struct {
+	Evidence string `json:"evidence,omitempty"` // researcher findings for or against the idea
+	Risks    string `json:"risks,omitempty"`    // critic's risks and open questions
+	Plan     string `json:"plan,omitempty"`     // implementer's practical plan
+
+	// Notes holds findings from custom roles that join the deep dive, by role
+	Notes map[string]string `json:"notes,omitempty"`
+}
+
+// Orders runDeepDives can pick its top ideas in
+const (
+	DeepDiveByScore    = "score"    // highest score first
+	DeepDiveByActivity = "activity" // no idea scored yet: most revised and merged first
+)
+
+// IsEmpty reports whether no agent contributed to the deep dive
+func (r *DeepDiveResult) IsEmpty() bool {
+	return r.Evidence == "" && r.Risks == "" && r.Plan == "" && len(r.Notes) == 0
+}
+
+// IdeaHandle returns the handle agents use for the idea at index i: its
+// stored Handle, or "I<i+1>" for ideas added without one
+func (d *Discussion) IdeaHandle(i int) string {
+	if h := d.Ideas[i].Handle; h != "" {
+		return h
+	}
+	return fmt.Sprintf("I%d", i+1)
 }
 
 // Discussion represents the complete discussion session
@@ -34,10 +155,97 @@ type Discussion struct {
 	Messages  []Message `json:"messages"`
 	Ideas     []Idea    `json:"ideas"`
 	FinalIdea *Idea     `json:"final_idea"`
-	Summary   string    `json:"summary"`
+	Summary   string    `json:"summary"`    // executive summary of the outcome
 	Status    string    `json:"status"`     // "running", "completed", "failed"
 	Round     int       `json:"round"`      // Current discussion round
 	MaxRounds int       `json:"max_rounds"` // Maximum rounds to run
+
+	// StopReason records why the round loop ended (see StopReason* constants)
+	StopReason string `json:"stop_reason,omitempty"`
+
+	// RoundSummaries holds the leader's synthesis of each exploration round
+	RoundSummaries []RoundSummary `json:"round_summaries,omitempty"`
+
+	// Convergence holds the per-round convergence signals in adaptive mode
+	Convergence []ConvergenceSignal `json:"convergence,omitempty"`
+
+	// DeepDiveOrder records how ideas were picked for deep dives (DeepDiveBy*)
+	DeepDiveOrder string `json:"deep_dive_order,omitempty"`
+
+	// Tournament holds the pairwise ranking results when the tournament phase ran
+	Tournament *TournamentResult `json:"tournament,omitempty"`
+
+	// Criteria are the weighted criteria ideas are scored on
+	Criteria []Criterion `json:"criteria,omitempty"`
+
+	// UnscoredIdeas lists the IDs of ideas final validation left without a score
+	UnscoredIdeas []string `json:"unscored_ideas,omitempty"`
+
+	// Budget records budget limits, estimated spend and any degradation
+	Budget *BudgetStatus `json:"budget,omitempty"`
+
+	// Metrics records the timing and spend of every phase and LLM call
+	Metrics *RunMetrics `json:"metrics,omitempty"`
+
+	// Failures records the agent contributions that failed, with their cause
+	Failures []AgentFailure `json:"failures,omitempty"`
+
+	// Recalled holds the past discussions retrieved from team memory for this one
+	Recalled []MemoryEntry `json:"recalled,omitempty"`
+
+	// Drill-down links: the discussion this one follows up on, what it
+	// inherited from it, and the follow-ups started from this one
+	ParentID string          `json:"parent_id,omitempty"`
+	Seed     *DiscussionSeed `json:"seed,omitempty"`
+	ChildIDs []string        `json:"child_ids,omitempty"`
+
+	// Checkpoints record the state at each round boundary so the
+	// discussion can be forked there (see ForkDiscussion)
+	Checkpoints []Checkpoint `json:"checkpoints,omitempty"`
+
+	// Fork links: the discussion this one branched from and the round it
+	// branched after, and the forks branched from this one
+	ForkOf    string   `json:"fork_of,omitempty"`
+	ForkRound int      `json:"fork_round,omitempty"`
+	ForkIDs   []string `json:"fork_ids,omitempty"`
+}
+
+// TournamentResult records a pairwise ranking tournament between ideas
+type TournamentResult struct {
+	Format  string          `json:"format"`   // "swiss"
+	IdeaIDs []string        `json:"idea_ids"` // contenders, indexing WinMatrix
+	Matches []PairwiseMatch `json:"matches"`
+
+	// WinMatrix[i][j] counts how often IdeaIDs[i] beat IdeaIDs[j]
+	WinMatrix [][]int `json:"win_matrix"`
+}
+
+// PairwiseMatch is a single head-to-head comparison judged by an agent
+type PairwiseMatch struct {
+	Round  int    `json:"round"`
+	IdeaA  string `json:"idea_a"`
+	IdeaB  string `json:"idea_b"`
+	Winner string `json:"winner"` // ID of the winning idea
+	Reason string `json:"reason,omitempty"`
+	Judge  string `json:"judge"`
+}
+
+// Reasons the round loop can end
+const (
+	StopReasonMaxRounds = "max_rounds" // fixed round count reached
+	StopReasonConverged = "converged"  // adaptive mode: the discussion settled
+	StopReasonRoundCap  = "round_cap"  // adaptive mode: hard cap reached without converging
+	StopReasonBudget    = "budget"     // budget ran low before the planned rounds finished
+)
+
+// ConvergenceSignal measures how settled the discussion was at the end of a round
+type ConvergenceSignal struct {
+	Round            int     `json:"round"`
+	NewIdeas         int     `json:"new_ideas"`         // ideas added this round
+	NewIdeaRate      float64 `json:"new_idea_rate"`     // new ideas relative to those that existed before the round
+	ScoreDelta       float64 `json:"score_delta"`       // mean absolute score change since last round, -1 if unknown
+	LeaderConfidence float64 `json:"leader_confidence"` // 0-1, leader's confidence the team has converged, -1 if not given
+	Converged        bool    `json:"converged"`
 }
 
 // AgentRole defines the role of an agent
@@ -58,6 +266,8 @@ type AgentResponse struct {
 	AgentRole     AgentRole              `json:"agent_role"`
 	Content       string                 `json:"content"`
 	Ideas         []Idea                 `json:"ideas,omitempty"`
+	Risks         []IdeaRisk             `json:"risks,omitempty"`
+	Plans         []IdeaPlan             `json:"plans,omitempty"`
 	Metadata      map[string]interface{} `json:"metadata,omitempty"`
 	SearchResults []interface{}          `json:"search_results,omitempty"` // tools.SearchResult (interface to avoid import cycle)
 }
@@ -14,13 +14,14 @@ import (
 )
 
 // Client implements llm.Client for OpenAI-compatible APIs.
-// It also implements llm.StreamingClient and llm.ToolCallingClient.
+// It also implements llm.StreamingClient, llm.ToolCallingClient and llm.EmbeddingClient.
 type Client struct {
-	APIKey  string
-	Model   string
-	BaseURL string // e.g. "https://llm-proxy-api.ai.eng.netapp.com/v1"
-	User    string // optional "user" field sent in request body (required by some proxies)
-	client  *http.Client
+	APIKey         string
+	Model          string
+	BaseURL        string // e.g. "https://llm-proxy-api.ai.eng.netapp.com/v1"
+	User           string // optional "user" field sent in request body (required by some proxies)
+	EmbeddingModel string // optional; defaults to LLM_EMBEDDING_MODEL or DefaultEmbeddingModel
+	client         *http.Client
 	// noTemperature is set to true after the model rejects a temperature parameter,
 	// so all subsequent requests skip it without needing a retry round-trip.
 	noTemperature bool
@@ -242,10 +243,10 @@ func (c *Client) doRequestFull(req chatRequest) (content string, toolCalls []api
 				return "", nil, "", err
 			}
 			if statusCode != http.StatusOK {
-				return "", nil, "", fmt.Errorf("API error (status %d): %s", statusCode, string(body))
+				return "", nil, "", &llm.APIError{StatusCode: statusCode, Body: string(body)}
 			}
 		} else {
-			return "", nil, "", fmt.Errorf("API error (status %d): %s", statusCode, bodyStr)
+			return "", nil, "", &llm.APIError{StatusCode: statusCode, Body: bodyStr}
 		}
 	}
 
@@ -254,7 +255,7 @@ func (c *Client) doRequestFull(req chatRequest) (content string, toolCalls []api
 		return "", nil, "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 	if len(apiResp.Choices) == 0 {
-		return "", nil, "", fmt.Errorf("no choices in response")
+		return "", nil, "", fmt.Errorf("%w: no choices", llm.ErrMalformedResponse)
 	}
 
 	choice := apiResp.Choices[0]
@@ -297,7 +298,7 @@ func (c *Client) doStream(req chatRequest, onChunk func(string)) (string, error)
 			req.Temperature = nil
 			return c.doStream(req, onChunk)
 		}
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, bodyStr)
+		return "", &llm.APIError{StatusCode: resp.StatusCode, Body: bodyStr}
 	}
 
 	var sb strings.Builder
@@ -0,0 +1,92 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// DefaultEmbeddingModel is used when neither Client.EmbeddingModel nor the
+// LLM_EMBEDDING_MODEL env var is set.
+const DefaultEmbeddingModel = "text-embedding-3-small"
+
+// embeddingRequest is the OpenAI embeddings request body.
+type embeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+	User  string   `json:"user,omitempty"`
+}
+
+// embeddingResponse is the OpenAI embeddings response body.
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// ResolvedEmbeddingModel returns the model Embed uses: Client.EmbeddingModel,
+// else LLM_EMBEDDING_MODEL, else DefaultEmbeddingModel.
+func (c *Client) ResolvedEmbeddingModel() string {
+	if c.EmbeddingModel != "" {
+		return c.EmbeddingModel
+	}
+	if model := os.Getenv("LLM_EMBEDDING_MODEL"); model != "" {
+		return model
+	}
+	return DefaultEmbeddingModel
+}
+
+// Embed returns one embedding vector per input text, in input order.
+// Implements llm.EmbeddingClient.
+func (c *Client) Embed(texts []string) ([][]float64, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	jsonData, err := json.Marshal(embeddingRequest{Model: c.ResolvedEmbeddingModel(), Input: texts, User: c.User})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	httpReq, err := http.NewRequest("POST", c.BaseURL+"/embeddings", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("request create error: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+c.APIKey)
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("embedding request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embeddings API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp embeddingResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal embeddings: %w", err)
+	}
+	if len(apiResp.Data) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(apiResp.Data))
+	}
+
+	vectors := make([][]float64, len(texts))
+	for _, d := range apiResp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
@@ -0,0 +1,414 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/ai-agent-team/internal/llm"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// Shares of the discussion budget at which the orchestrator degrades. The
+// last 15% is kept in reserve for final validation and the report.
+const (
+	budgetLowAt      = 0.5
+	budgetCriticalAt = 0.7
+	budgetReserveAt  = 0.85
+)
+
+// budgetLevels orders the budget levels by increasing spend
+var budgetLevels = []string{models.BudgetOK, models.BudgetLow, models.BudgetCritical, models.BudgetReserve, models.BudgetExhausted}
+
+// budgetRank returns the position of level in budgetLevels
+func budgetRank(level string) int {
+	for i, l := range budgetLevels {
+		if l == level {
+			return i
+		}
+	}
+	return 0
+}
+
+// budgetLevelFor maps the share of the budget used to a level
+func budgetLevelFor(fraction float64) string {
+	switch {
+	case fraction >= 1:
+		return models.BudgetExhausted
+	case fraction >= budgetReserveAt:
+		return models.BudgetReserve
+	case fraction >= budgetCriticalAt:
+		return models.BudgetCritical
+	case fraction >= budgetLowAt:
+		return models.BudgetLow
+	}
+	return models.BudgetOK
+}
+
+// errBudgetExhausted is returned by metered clients instead of calling the
+// backend once the discussion budget is spent.
+var errBudgetExhausted = errors.New("discussion budget exhausted")
+
+// usageMeter accumulates estimated LLM spend per role. Clients record into it
+// from whichever goroutine runs the call.
+type usageMeter struct {
+	mu     sync.Mutex
+	total  models.BudgetUsage
+	byRole map[models.AgentRole]models.BudgetUsage
+	limits models.Budget // the discussion budget, checked before each call
+	start  time.Time     // when the discussion started, for the time limit
+}
+
+func newUsageMeter() *usageMeter {
+	return &usageMeter{byRole: make(map[models.AgentRole]models.BudgetUsage)}
+}
+
+// reset clears all recorded usage and sets the budget of a discussion
+// starting at start
+func (m *usageMeter) reset(limits models.Budget, start time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.total = models.BudgetUsage{}
+	m.byRole = make(map[models.AgentRole]models.BudgetUsage)
+	m.limits, m.start = limits, start
+}
+
+// exhausted reports whether the discussion budget is spent
+func (m *usageMeter) exhausted() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.limits.IsZero() {
+		return false
+	}
+	used := m.total
+	used.Duration = time.Since(m.start)
+	return budgetLevelFor(m.limits.Fraction(used)) == models.BudgetExhausted
+}
+
+// record adds one call's estimated spend
+func (m *usageMeter) record(role models.AgentRole, tokens int, cost float64, d time.Duration) {
+	m.add(role, models.BudgetUsage{Tokens: tokens, Cost: cost, Duration: d, Calls: 1})
+}
+
+// recordTokens adds spend inside a call that is still running, such as a
+// tool result fed back to the model
+func (m *usageMeter) recordTokens(role models.AgentRole, tokens int, cost float64) {
+	m.add(role, models.BudgetUsage{Tokens: tokens, Cost: cost})
+}
+
+func (m *usageMeter) add(role models.AgentRole, u models.BudgetUsage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sum := func(v models.BudgetUsage) models.BudgetUsage {
+		v.Tokens += u.Tokens
+		v.Cost += u.Cost
+		v.Duration += u.Duration
+		v.Calls += u.Calls
+		return v
+	}
+	m.total = sum(m.total)
+	m.byRole[role] = sum(m.byRole[role])
+}
+
+// usage returns the total spend and a copy of the per-role spend
+func (m *usageMeter) usage() (models.BudgetUsage, map[string]models.BudgetUsage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	byRole := make(map[string]models.BudgetUsage, len(m.byRole))
+	for role, u := range m.byRole {
+		byRole[string(role)] = u
+	}
+	return m.total, byRole
+}
+
+// roleUsage returns the spend of one role
+func (m *usageMeter) roleUsage(role models.AgentRole) models.BudgetUsage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.byRole[role]
+}
+
+// meteredClient wraps an llm.Client and records the estimated tokens, cost
+// and call time of every request against a role. Once the discussion budget
+// is exhausted it refuses calls with errBudgetExhausted.
+type meteredClient struct {
+	inner   llm.Client
+	role    models.AgentRole
+	model   string
+	meter   *usageMeter
+	metrics *metricsRecorder
+}
+
+// meteredToolClient adds tool calling for backends that support it, so the
+// agent's llm.ToolCallingClient type assertion keeps working.
+type meteredToolClient struct {
+	*meteredClient
+}
+
+// meterClient wraps client so its calls count against role's budget.
+func (o *ConfigurableOrchestrator) meterClient(role models.AgentRole, model string, client llm.Client) llm.Client {
+	mc := &meteredClient{inner: client, role: role, model: model, meter: o.usage, metrics: o.metrics}
+	if _, ok := client.(llm.ToolCallingClient); ok {
+		return &meteredToolClient{mc}
+	}
+	return mc
+}
+
+// embeddingRole is the role embedding calls are metered under. They are made
+// for dedup and team memory rather than by an agent.
+const embeddingRole models.AgentRole = "embeddings"
+
+// meteredEmbedder records the estimated tokens, cost and call time of every
+// embedding request, like meteredClient does for chat requests.
+type meteredEmbedder struct {
+	inner llm.EmbeddingClient
+	mc    *meteredClient
+}
+
+// meterEmbedder wraps embedder so its calls count against the run budget and
+// show in the run metrics. Clients that report their embedding model are
+// priced by it; others by the backend's chat model.
+func (o *ConfigurableOrchestrator) meterEmbedder(embedder llm.EmbeddingClient) llm.EmbeddingClient {
+	model := o.BackendConfig.Model
+	if m, ok := embedder.(interface{ ResolvedEmbeddingModel() string }); ok {
+		model = m.ResolvedEmbeddingModel()
+	}
+	return &meteredEmbedder{
+		inner: embedder,
+		mc:    &meteredClient{role: embeddingRole, model: model, meter: o.usage, metrics: o.metrics},
+	}
+}
+
+func (e *meteredEmbedder) Embed(texts []string) ([][]float64, error) {
+	if e.mc.meter.exhausted() {
+		return nil, errBudgetExhausted
+	}
+	start := time.Now()
+	vecs, err := e.inner.Embed(texts)
+	messages := make([]llm.Message, len(texts))
+	for i, text := range texts {
+		messages[i] = llm.Message{Role: "user", Content: text}
+	}
+	e.mc.track(start, messages, "", "", err)
+	return vecs, err
+}
+
+// track records a finished call in the budget meter and the run metrics
+func (c *meteredClient) track(start time.Time, messages []llm.Message, systemPrompt, response string, err error) {
+	c.trackWithTools(start, messages, systemPrompt, response, 0, err)
+}
+
+// trackWithTools records a finished call whose toolTokens of tool results
+// were already added to the meter as they arrived
+func (c *meteredClient) trackWithTools(start time.Time, messages []llm.Message, systemPrompt, response string, toolTokens int, err error) {
+	in := llm.EstimateTokens(systemPrompt)
+	for _, m := range messages {
+		in += llm.EstimateTokens(m.Content)
+	}
+	out := llm.EstimateTokens(response)
+	cost := llm.EstimateCost(c.model, in, out)
+	elapsed := time.Since(start)
+	c.meter.record(c.role, in+out, cost, elapsed)
+
+	call := models.CallMetrics{
+		Role:         string(c.role),
+		Model:        c.model,
+		Start:        start,
+		Duration:     elapsed,
+		InputTokens:  in + toolTokens,
+		OutputTokens: out,
+		Cost:         cost + llm.EstimateCost(c.model, toolTokens, 0),
+		Outcome:      models.OutcomeOK,
+	}
+	if err != nil {
+		call.Outcome, call.Error = models.OutcomeError, err.Error()
+	}
+	c.metrics.record(call)
+}
+
+func (c *meteredClient) SendMessage(messages []llm.Message, systemPrompt string, temperature float64) (string, error) {
+	if c.meter.exhausted() {
+		return "", errBudgetExhausted
+	}
+	start := time.Now()
+	resp, err := c.inner.SendMessage(messages, systemPrompt, temperature)
+	c.track(start, messages, systemPrompt, resp, err)
+	return resp, err
+}
+
+func (c *meteredClient) SendMessageWithTokens(messages []llm.Message, systemPrompt string, temperature float64, maxTokens int) (string, error) {
+	if c.meter.exhausted() {
+		return "", errBudgetExhausted
+	}
+	start := time.Now()
+	resp, err := c.inner.SendMessageWithTokens(messages, systemPrompt, temperature, maxTokens)
+	c.track(start, messages, systemPrompt, resp, err)
+	return resp, err
+}
+
+func (c *meteredClient) SimpleQuery(query string, systemPrompt string) (string, error) {
+	if c.meter.exhausted() {
+		return "", errBudgetExhausted
+	}
+	start := time.Now()
+	resp, err := c.inner.SimpleQuery(query, systemPrompt)
+	c.track(start, []llm.Message{{Role: "user", Content: query}}, systemPrompt, resp, err)
+	return resp, err
+}
+
+// SendMessageStream streams when the backend supports it, otherwise it sends
+// a blocking request and emits the result as a single chunk.
+func (c *meteredClient) SendMessageStream(messages []llm.Message, systemPrompt string, temperature float64, onChunk func(string)) (string, error) {
+	if c.meter.exhausted() {
+		return "", errBudgetExhausted
+	}
+	start := time.Now()
+	var resp string
+	var err error
+	if sc, ok := c.inner.(llm.StreamingClient); ok {
+		resp, err = sc.SendMessageStream(messages, systemPrompt, temperature, onChunk)
+	} else if resp, err = c.inner.SendMessage(messages, systemPrompt, temperature); err == nil {
+		onChunk(resp)
+	}
+	c.track(start, messages, systemPrompt, resp, err)
+	return resp, err
+}
+
+// SendMessageWithTools counts the initial request, every tool result fed
+// back to the model and the final answer. Tools stop running once the budget
+// is exhausted mid-loop; the model sees the refusal as a tool error.
+func (c *meteredToolClient) SendMessageWithTools(messages []llm.Message, systemPrompt string, temperature float64, tools []llm.ToolDefinition, executeTool func(name, arguments string) (string, error)) (string, error) {
+	if c.meter.exhausted() {
+		return "", errBudgetExhausted
+	}
+	start := time.Now()
+	toolTokens := 0 // tools run one at a time, in the client's loop
+	metered := func(name, arguments string) (string, error) {
+		if c.meter.exhausted() {
+			return "", errBudgetExhausted
+		}
+		result, err := executeTool(name, arguments)
+		tokens := llm.EstimateTokens(result)
+		c.meter.recordTokens(c.role, tokens, llm.EstimateCost(c.model, tokens, 0))
+		toolTokens += tokens
+		return result, err
+	}
+	resp, err := c.inner.(llm.ToolCallingClient).SendMessageWithTools(messages, systemPrompt, temperature, tools, metered)
+	c.trackWithTools(start, messages, systemPrompt, resp, toolTokens, err)
+	return resp, err
+}
+
+// updateBudget refreshes Discussion.Budget from the meter and announces
+// when the budget level rises. Levels never fall during a discussion.
+func (o *ConfigurableOrchestrator) updateBudget() *models.BudgetStatus {
+	if o.Discussion == nil || o.Discussion.Budget == nil {
+		return &models.BudgetStatus{Level: models.BudgetOK}
+	}
+	b := o.Discussion.Budget
+	used, byRole := o.usage.usage()
+	used.Duration = time.Since(o.Discussion.StartTime)
+	b.Used = used
+	b.AgentUsage = byRole
+	b.Fraction = b.Limits.Fraction(used)
+
+	if level := budgetLevelFor(b.Fraction); budgetRank(level) > budgetRank(b.Level) {
+		b.Level = level
+		o.notify(fmt.Sprintf("  💸 Budget %s: %.0f%% used (%s)", level, b.Fraction*100, formatUsage(used)))
+	}
+	return b
+}
+
+// budgetAllows reports whether step may run, refusing once the budget has
+// reached skipAt. Refusals are recorded as degradations.
+func (o *ConfigurableOrchestrator) budgetAllows(step, skipAt string) bool {
+	b := o.updateBudget()
+	if budgetRank(b.Level) < budgetRank(skipAt) {
+		return true
+	}
+	o.degrade(fmt.Sprintf("%s skipped (budget %s)", step, b.Level))
+	return false
+}
+
+// agentAllowed reports whether role may take an exploration turn: optional
+// agents stop when the budget runs low, every agent stops in the reserve or
+// once it has spent its own per-agent budget.
+func (o *ConfigurableOrchestrator) agentAllowed(role models.AgentRole, name string) bool {
+	if o.agentOverBudget(role) {
+		return false
+	}
+	skipAt := models.BudgetReserve
+	if isOptionalRole(role) {
+		skipAt = models.BudgetLow
+	}
+	return o.budgetAllows(name, skipAt)
+}
+
+// agentOverBudget reports whether role has used up its per-agent budget,
+// recording the first time it does.
+func (o *ConfigurableOrchestrator) agentOverBudget(role models.AgentRole) bool {
+	limits := o.Config.AgentBudget
+	if limits.IsZero() || limits.Fraction(o.usage.roleUsage(role)) < 1 {
+		return false
+	}
+	if b := o.Discussion.Budget; b != nil {
+		for _, r := range b.AgentsOverBudget {
+			if r == string(role) {
+				return true
+			}
+		}
+		b.AgentsOverBudget = append(b.AgentsOverBudget, string(role))
+	}
+	o.degrade(fmt.Sprintf("%s benched: per-agent budget spent", role))
+	return true
+}
+
+// degrade records a step skipped to stay within budget, labelled with the
+// round or phase it happened in
+func (o *ConfigurableOrchestrator) degrade(what string) {
+	switch o.phase {
+	case PhaseExploration, PhaseSynthesis, PhaseConvergence:
+		what = fmt.Sprintf("round %d: %s", o.Discussion.Round, what)
+	case "":
+	default:
+		what = fmt.Sprintf("%s: %s", o.phase.Label(), what)
+	}
+	if b := o.Discussion.Budget; b != nil {
+		b.Degradations = append(b.Degradations, what)
+	}
+	o.notify("  💸 " + what)
+}
+
+// isOptionalRole reports whether a discussion can do without role's
+// exploration turns. The leader, ideation, moderator and UI creator are core.
+func isOptionalRole(role models.AgentRole) bool {
+	switch role {
+	case models.RoleTeamLeader, models.RoleIdeation, models.RoleModerator, models.RoleUICreator:
+		return false
+	}
+	return true
+}
+
+// formatUsage renders usage as "12.3k tokens, $0.42, 3m10s"
+func formatUsage(u models.BudgetUsage) string {
+	return fmt.Sprintf("%.1fk tokens, $%.2f, %s", float64(u.Tokens)/1000, u.Cost, u.Duration.Round(time.Second))
+}
+
+// formatBudget renders budget limits, e.g. "50k tokens, $2.00, 10m0s"
+func formatBudget(b models.Budget) string {
+	if b.IsZero() {
+		return "unlimited"
+	}
+	var parts []string
+	if b.MaxTokens > 0 {
+		parts = append(parts, fmt.Sprintf("%dk tokens", b.MaxTokens/1000))
+	}
+	if b.MaxCost > 0 {
+		parts = append(parts, fmt.Sprintf("$%.2f", b.MaxCost))
+	}
+	if b.MaxDuration > 0 {
+		parts = append(parts, b.MaxDuration.String())
+	}
+	return strings.Join(parts, ", ")
+}
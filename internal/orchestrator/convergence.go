@@ -0,0 +1,141 @@
+package orchestrator
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+const (
+	// convergedNewIdeaRate is the highest new-idea rate that still counts as converged.
+	convergedNewIdeaRate = 0.25
+	// convergedScoreDelta is the largest mean score change that still counts as converged.
+	convergedScoreDelta = 0.5
+	// convergedConfidence is the lowest leader confidence that counts as converged.
+	convergedConfidence = 0.7
+)
+
+// convergencePrompt is appended to the leader's synthesis prompt in adaptive mode.
+const convergencePrompt = `
+
+Finish with a final line of the form "CONVERGENCE: <0.0-1.0>" giving your confidence that the team has settled on its strongest ideas and another round would add little.`
+
+var convergenceLine = regexp.MustCompile(`(?i)convergence\**\s*:\s*\**\s*([0-9]*\.?[0-9]+)`)
+
+// checkConvergence measures whether the round that just finished moved the
+// discussion: how many new ideas appeared, how much interim scores shifted,
+// and how confident the leader is. The signal is recorded on the discussion.
+// It returns an error only when interim scoring fails with StrictFailures.
+func (o *ConfigurableOrchestrator) checkConvergence(round, ideasBefore int) (models.ConvergenceSignal, error) {
+	o.startPhase(PhaseConvergence)
+
+	sig := models.ConvergenceSignal{
+		Round:            round,
+		NewIdeas:         len(o.Discussion.Ideas) - ideasBefore,
+		NewIdeaRate:      1,
+		ScoreDelta:       -1,
+		LeaderConfidence: o.leaderConfidence(),
+	}
+	if ideasBefore > 0 {
+		sig.NewIdeaRate = float64(sig.NewIdeas) / float64(ideasBefore)
+	}
+
+	scores, err := o.interimScores()
+	if err != nil {
+		return sig, err
+	}
+	if scores != nil {
+		sig.ScoreDelta = scoreDelta(o.lastScores, scores)
+		o.lastScores = scores
+	}
+
+	sig.Converged = sig.NewIdeaRate <= convergedNewIdeaRate &&
+		(sig.ScoreDelta >= 0 || sig.LeaderConfidence >= 0) &&
+		(sig.ScoreDelta < 0 || sig.ScoreDelta <= convergedScoreDelta) &&
+		(sig.LeaderConfidence < 0 || sig.LeaderConfidence >= convergedConfidence)
+
+	o.Discussion.Convergence = append(o.Discussion.Convergence, sig)
+	o.notify(fmt.Sprintf("  📉 Convergence: %d new ideas (rate %.2f), score Δ %s, leader confidence %s → converged: %v",
+		sig.NewIdeas, sig.NewIdeaRate, formatSignal(sig.ScoreDelta), formatSignal(sig.LeaderConfidence), sig.Converged))
+
+	return sig, nil
+}
+
+// interimScores asks the moderator for a quick scoring pass and returns the
+// scores of all validated ideas, or nil when there is no moderator or it
+// fails. A failed call is recorded, and only returned as an error with
+// StrictFailures.
+func (o *ConfigurableOrchestrator) interimScores() (map[string]float64, error) {
+	moderator, ok := o.Agents[models.RoleModerator]
+	if !ok || len(o.Discussion.Ideas) == 0 {
+		return nil, nil
+	}
+
+	response, err := o.process(models.RoleModerator, moderator, o.Discussion,
+		"Give interim scores for all ideas so far so the team can tell whether the discussion is converging")
+	if err != nil {
+		return nil, o.recordFailure(models.RoleModerator, moderator.GetName(), fmt.Errorf("interim scoring: %w", err))
+	}
+	o.addMessage("system", string(models.RoleModerator), response.Content, "interim_validation")
+
+	scores := make(map[string]float64)
+	for _, idea := range o.Discussion.Ideas {
+		if idea.Validated {
+			scores[idea.ID] = idea.Score
+			o.emitIdea(EventIdeaScored, models.RoleModerator, idea)
+		}
+	}
+	return scores, nil
+}
+
+// leaderConfidence parses the CONVERGENCE line from the latest synthesis,
+// returning -1 when the leader did not give one.
+func (o *ConfigurableOrchestrator) leaderConfidence() float64 {
+	for i := len(o.Discussion.Messages) - 1; i >= 0; i-- {
+		msg := o.Discussion.Messages[i]
+		if msg.Type != "synthesis" {
+			continue
+		}
+		matches := convergenceLine.FindAllStringSubmatch(msg.Content, -1)
+		if len(matches) == 0 {
+			return -1
+		}
+		v, err := strconv.ParseFloat(matches[len(matches)-1][1], 64)
+		if err != nil {
+			return -1
+		}
+		if v > 1 && v <= 100 {
+			v /= 100 // percentage
+		}
+		return math.Min(math.Max(v, 0), 1)
+	}
+	return -1
+}
+
+// scoreDelta returns the mean absolute change for ideas scored in both
+// snapshots, or -1 when there is nothing to compare.
+func scoreDelta(prev, cur map[string]float64) float64 {
+	var sum float64
+	n := 0
+	for id, score := range cur {
+		if old, ok := prev[id]; ok {
+			sum += math.Abs(score - old)
+			n++
+		}
+	}
+	if n == 0 {
+		return -1
+	}
+	return sum / float64(n)
+}
+
+// formatSignal renders an optional convergence measurement.
+func formatSignal(v float64) string {
+	if v < 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.2f", v)
+}
@@ -0,0 +1,245 @@
+package orchestrator
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/yourusername/ai-agent-team/internal/llm"
+	"github.com/yourusername/ai-agent-team/internal/llmfactory"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+const (
+	// embeddingDupThreshold is the cosine similarity above which two ideas
+	// are treated as duplicates when embeddings are available.
+	embeddingDupThreshold = 0.88
+	// lexicalDupThreshold is the token-set Jaccard similarity above which two
+	// ideas are treated as duplicates when falling back to lexical matching.
+	lexicalDupThreshold = 0.5
+)
+
+// ideaDeduper finds near-duplicate ideas, preferring embeddings and falling
+// back to lexical overlap when the backend has no embedding support or the
+// embedding call fails.
+type ideaDeduper struct {
+	embedder llm.EmbeddingClient
+	vectors  map[string][]float64 // idea ID → embedding
+}
+
+// newIdeaDeduper creates a deduper that embeds with embedder, or matches
+// lexically when embedder is nil.
+func newIdeaDeduper(embedder llm.EmbeddingClient) *ideaDeduper {
+	return &ideaDeduper{embedder: embedder, vectors: make(map[string][]float64)}
+}
+
+// probeEmbedder returns the backend's embedding client, metered like the
+// agents' clients, or nil when the backend has none.
+func (o *ConfigurableOrchestrator) probeEmbedder() llm.EmbeddingClient {
+	if o.BackendConfig == nil {
+		return nil
+	}
+	client, err := llmfactory.NewClient(o.BackendConfig)
+	if err != nil {
+		return nil
+	}
+	ec, ok := client.(llm.EmbeddingClient)
+	if !ok {
+		return nil
+	}
+	return o.meterEmbedder(ec)
+}
+
+// findDuplicate returns the index of the existing idea that candidate
+// duplicates, with the similarity and the method used, or -1 if none does.
+// The ideas candidate builds on are never matches: a refinement is expected
+// to resemble its parent, and merging it away would lose the revision.
+func (d *ideaDeduper) findDuplicate(candidate models.Idea, existing []models.Idea) (int, float64, string) {
+	if len(existing) == 0 {
+		return -1, 0, ""
+	}
+
+	if d.embedder != nil {
+		idx, sim, err := d.findByEmbedding(candidate, existing)
+		if err == nil {
+			return idx, sim, "embedding"
+		}
+		log.Printf("Warning: idea embedding failed, using lexical dedup: %v", err)
+		d.embedder = nil
+	}
+
+	return d.findByLexical(candidate, existing)
+}
+
+// findByEmbedding compares cosine similarity of idea embeddings, embedding
+// any ideas not yet cached in a single batch.
+func (d *ideaDeduper) findByEmbedding(candidate models.Idea, existing []models.Idea) (int, float64, error) {
+	var ids, texts []string
+	for _, idea := range append([]models.Idea{candidate}, existing...) {
+		if _, ok := d.vectors[idea.ID]; !ok {
+			ids = append(ids, idea.ID)
+			texts = append(texts, ideaText(idea))
+		}
+	}
+	if len(texts) > 0 {
+		vectors, err := d.embedder.Embed(texts)
+		if err != nil {
+			return -1, 0, err
+		}
+		for i, id := range ids {
+			d.vectors[id] = vectors[i]
+		}
+	}
+
+	best, bestSim := -1, 0.0
+	cv := d.vectors[candidate.ID]
+	for i, idea := range existing {
+		if slices.Contains(candidate.ParentIDs, idea.ID) {
+			continue
+		}
+		if sim := cosine(cv, d.vectors[idea.ID]); sim > bestSim {
+			best, bestSim = i, sim
+		}
+	}
+	if bestSim < embeddingDupThreshold {
+		return -1, bestSim, nil
+	}
+	return best, bestSim, nil
+}
+
+// findByLexical compares the Jaccard overlap of content-word sets.
+func (d *ideaDeduper) findByLexical(candidate models.Idea, existing []models.Idea) (int, float64, string) {
+	ct := ideaTokens(candidate)
+	best, bestSim := -1, 0.0
+	for i, idea := range existing {
+		if slices.Contains(candidate.ParentIDs, idea.ID) {
+			continue
+		}
+		if sim := jaccard(ct, ideaTokens(idea)); sim > bestSim {
+			best, bestSim = i, sim
+		}
+	}
+	if bestSim < lexicalDupThreshold {
+		return -1, bestSim, "lexical"
+	}
+	return best, bestSim, "lexical"
+}
+
+// addIdeas appends newly proposed ideas to the discussion, merging any that
+// duplicate an existing idea (or one added earlier in the same batch) into it.
+// It returns the ideas that were actually added.
+func (o *ConfigurableOrchestrator) addIdeas(role models.AgentRole, ideas []models.Idea) []models.Idea {
+	var added []models.Idea
+	for _, idea := range ideas {
+		o.linkLineage(&idea)
+		if o.Config.DedupIdeas {
+			if o.deduper == nil {
+				o.deduper = newIdeaDeduper(o.probeEmbedder())
+			}
+			idx, sim, method := o.deduper.findDuplicate(idea, o.Discussion.Ideas)
+			if idx >= 0 {
+				target := &o.Discussion.Ideas[idx]
+				target.MergedFrom = append(target.MergedFrom, models.IdeaProvenance{
+					ID:          idea.ID,
+					Title:       idea.Title,
+					Description: idea.Description,
+					CreatedBy:   idea.CreatedBy,
+					Similarity:  sim,
+					Method:      method,
+				})
+				if target.Category == "" {
+					target.Category = idea.Category
+				}
+				o.notify(fmt.Sprintf("    🔗 Merged duplicate %q into %q (%s similarity %.2f)", idea.Title, target.Title, method, sim))
+				continue
+			}
+		}
+
+		// Ideas are never removed, so the next position is a stable handle
+		idea.Handle = fmt.Sprintf("I%d", len(o.Discussion.Ideas)+1)
+		o.Discussion.Ideas = append(o.Discussion.Ideas, idea)
+		o.emitIdea(EventIdeaAdded, role, idea)
+		if len(idea.Revisions) > 0 {
+			parent := idea.Revisions[len(idea.Revisions)-1]
+			o.notify(fmt.Sprintf("    💡 New idea: %s (v%d, evolved from %q)", idea.Title, len(idea.Revisions)+1, parent.Title))
+		} else {
+			o.notify(fmt.Sprintf("    💡 New idea: %s", idea.Title))
+		}
+		o.warnIfRejectedBefore(idea)
+		added = append(added, idea)
+	}
+	return added
+}
+
+// ideaText is the text embedded for an idea.
+func ideaText(idea models.Idea) string {
+	return idea.Title + "\n" + idea.Description
+}
+
+// dedupStopwords are common words ignored by lexical matching.
+var dedupStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "that": true, "this": true,
+	"from": true, "into": true, "their": true, "they": true, "are": true, "can": true,
+	"will": true, "use": true, "using": true, "our": true, "your": true, "its": true,
+	"which": true, "by": true, "on": true, "of": true, "to": true, "in": true,
+	"a": true, "an": true, "or": true, "is": true, "be": true, "as": true, "at": true,
+	"it": true, "so": true, "more": true, "than": true, "based": true, "through": true,
+}
+
+// ideaTokens returns the set of normalized content words in an idea.
+func ideaTokens(idea models.Idea) map[string]bool {
+	return textTokens(ideaText(idea))
+}
+
+// textTokens returns the set of normalized content words in text.
+func textTokens(text string) map[string]bool {
+	tokens := make(map[string]bool)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if len(w) < 3 || dedupStopwords[w] {
+			continue
+		}
+		// Crude plural folding so "tools" matches "tool"
+		if len(w) > 4 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = strings.TrimSuffix(w, "s")
+		}
+		tokens[w] = true
+	}
+	return tokens
+}
+
+// jaccard returns |a ∩ b| / |a ∪ b|.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for t := range a {
+		if b[t] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
+
+// cosine returns the cosine similarity of two vectors (0 if either is empty).
+func cosine(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
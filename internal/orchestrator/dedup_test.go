@@ -0,0 +1,97 @@
+package orchestrator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// fakeEmbedder returns a fixed vector per idea text, or err for every call
+type fakeEmbedder struct {
+	vectors map[string][]float64
+	err     error
+	calls   int
+}
+
+func (f *fakeEmbedder) Embed(texts []string) ([][]float64, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make([][]float64, len(texts))
+	for i, text := range texts {
+		out[i] = f.vectors[text]
+	}
+	return out, nil
+}
+
+// TestFindDuplicateEmbeddingThreshold checks ideas merge only when their
+// embeddings are at least embeddingDupThreshold similar.
+func TestFindDuplicateEmbeddingThreshold(t *testing.T) {
+	existing := []models.Idea{
+		{ID: "a", Title: "Community fridges"},
+		{ID: "b", Title: "Surplus marketplace"},
+	}
+	candidate := models.Idea{ID: "c", Title: "Neighbourhood fridges"}
+
+	tests := []struct {
+		name    string
+		vector  []float64 // the candidate's embedding; a is {1, 0}, b is {0, 1}
+		wantIdx int
+	}{
+		{"identical", []float64{1, 0}, 0},
+		{"just above threshold", []float64{0.9, 0.43}, 0},   // cosine ≈ 0.902
+		{"just below threshold", []float64{0.85, 0.53}, -1}, // cosine ≈ 0.849
+		{"closer to the other idea", []float64{0.1, 1}, 1},
+		{"unrelated", []float64{0.7, 0.7}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			embedder := &fakeEmbedder{vectors: map[string][]float64{
+				ideaText(existing[0]): {1, 0},
+				ideaText(existing[1]): {0, 1},
+				ideaText(candidate):   tt.vector,
+			}}
+			idx, sim, method := newIdeaDeduper(embedder).findDuplicate(candidate, existing)
+			if idx != tt.wantIdx || method != "embedding" {
+				t.Errorf("findDuplicate = %d (%s similarity %.3f), want %d by embedding", idx, method, sim, tt.wantIdx)
+			}
+		})
+	}
+}
+
+// TestFindDuplicateLexicalFallback checks a failed embedding call falls back
+// to lexical matching, and that the deduper stops calling the embedder.
+func TestFindDuplicateLexicalFallback(t *testing.T) {
+	existing := []models.Idea{
+		{ID: "a", Title: "Community fridges", Description: "Shared fridges where neighbours leave surplus food"},
+		{ID: "b", Title: "Compost collection", Description: "Weekly pickup of kitchen scraps for compost"},
+	}
+	tests := []struct {
+		name      string
+		candidate models.Idea
+		wantIdx   int
+	}{
+		{"same words", models.Idea{ID: "c", Title: "Community fridges", Description: "Neighbours leave surplus food in shared fridges"}, 0},
+		{"plural folding", models.Idea{ID: "d", Title: "Compost collections", Description: "Weekly pickups of kitchen scraps for compost"}, 1},
+		{"different idea", models.Idea{ID: "e", Title: "Meal planning app", Description: "Plan meals to buy only what is needed"}, -1},
+	}
+
+	embedder := &fakeEmbedder{err: errors.New("embeddings unavailable")}
+	d := newIdeaDeduper(embedder)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx, sim, method := d.findDuplicate(tt.candidate, existing)
+			if idx != tt.wantIdx || method != "lexical" {
+				t.Errorf("findDuplicate = %d (%s similarity %.2f), want %d by lexical", idx, method, sim, tt.wantIdx)
+			}
+			if tt.wantIdx >= 0 && sim < lexicalDupThreshold {
+				t.Errorf("merged at similarity %.2f, below the threshold %.2f", sim, lexicalDupThreshold)
+			}
+		})
+	}
+	if embedder.calls != 1 {
+		t.Errorf("embedder called %d times, want once before falling back", embedder.calls)
+	}
+}
@@ -0,0 +1,179 @@
+package orchestrator
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// defaultDeepDiveTopK is used when TeamConfig.DeepDiveTopK is not set.
+const defaultDeepDiveTopK = 3
+
+// deepDiveStep is one agent's turn in a focused mini-round.
+type deepDiveStep struct {
+	role   models.AgentRole
+	prompt string
+	store  func(result *models.DeepDiveResult, content string)
+}
+
+// deepDiveSteps is the fixed sequence for each focused mini-round:
+// researcher gathers evidence, critic probes risks, implementer plans.
+var deepDiveSteps = []deepDiveStep{
+	{
+		role:   models.RoleResearcher,
+		prompt: "Deep dive on the idea %q. Gather evidence that supports or undermines it: comparable products, data points, precedents and market signals.",
+		store:  func(r *models.DeepDiveResult, c string) { r.Evidence = c },
+	},
+	{
+		role:   models.RoleCritic,
+		prompt: "Deep dive on the idea %q. Using the evidence gathered, identify its most serious risks, failure modes and the open questions it must answer.",
+		store:  func(r *models.DeepDiveResult, c string) { r.Risks = c },
+	},
+	{
+		role:   models.RoleImplementer,
+		prompt: "Deep dive on the idea %q. Given the evidence and risks raised, outline a concrete implementation plan: MVP scope, phases, resources and first steps.",
+		store:  func(r *models.DeepDiveResult, c string) { r.Plan = c },
+	},
+}
+
+// deepDiveSteps returns the built-in steps followed by a step for each custom
+// or registered role that joins the deep dive, whose findings are stored in Notes.
+func (o *ConfigurableOrchestrator) deepDiveSteps() []deepDiveStep {
+	steps := append([]deepDiveStep(nil), deepDiveSteps...)
+	for _, turn := range o.phaseTurns(models.CustomPhaseDeepDive) {
+		role := string(turn.role)
+		steps = append(steps, deepDiveStep{
+			role:   turn.role,
+			prompt: "Deep dive on the idea %q. " + strings.ReplaceAll(turn.prompt, "%", "%%"),
+			store: func(r *models.DeepDiveResult, c string) {
+				if r.Notes == nil {
+					r.Notes = make(map[string]string)
+				}
+				r.Notes[role] = c
+			},
+		})
+	}
+	return steps
+}
+
+// runDeepDives runs a focused mini-round on each of the top-K ideas. The
+// researcher, critic and implementer examine only that idea, and their output
+// is attached to the idea before final selection. Failed steps are recorded
+// on the discussion and only stop it with StrictFailures.
+func (o *ConfigurableOrchestrator) runDeepDives() error {
+	var participants int
+	for _, step := range o.deepDiveSteps() {
+		if _, ok := o.Agents[step.role]; ok {
+			participants++
+		}
+	}
+	if participants == 0 || len(o.Discussion.Ideas) == 0 {
+		return nil
+	}
+
+	k := o.Config.DeepDiveTopK
+	if k <= 0 {
+		k = defaultDeepDiveTopK
+	}
+
+	o.notify("\n🔬 Phase: Deep Dive")
+	o.startPhase(PhaseDeepDive)
+
+	top, order := o.topIdeaIndexes(k)
+	o.Discussion.DeepDiveOrder = order
+	if order == models.DeepDiveByActivity {
+		o.notify("  🔬 No idea is scored yet: deep diving the most revised and merged ideas")
+	}
+	for i, idx := range top {
+		if i > 0 && !o.budgetAllows("remaining deep dives", models.BudgetLow) {
+			break
+		}
+		if err := o.runDeepDive(&o.Discussion.Ideas[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runDeepDive runs the focused mini-round for a single idea.
+func (o *ConfigurableOrchestrator) runDeepDive(idea *models.Idea) error {
+	o.notify(fmt.Sprintf("  🔬 Deep diving: %s", idea.Title))
+
+	// The focused discussion only shows this idea, and accumulates the
+	// mini-round's own messages so each agent builds on the previous one.
+	focus := &models.Discussion{
+		ID:        o.Discussion.ID,
+		Topic:     o.Discussion.Topic,
+		StartTime: o.Discussion.StartTime,
+		Messages:  []models.Message{},
+		Ideas:     []models.Idea{*idea},
+		Status:    o.Discussion.Status,
+		Round:     o.Discussion.Round,
+		MaxRounds: o.Discussion.MaxRounds,
+	}
+
+	result := &models.DeepDiveResult{}
+	for _, step := range o.deepDiveSteps() {
+		agent, ok := o.Agents[step.role]
+		if !ok || !o.agentAllowed(step.role, agent.GetName()) {
+			continue
+		}
+
+		o.notify(fmt.Sprintf("  🗣️  %s contributing...", agent.GetName()))
+
+		response, err := o.process(step.role, agent, focus, fmt.Sprintf(step.prompt, idea.Title))
+		if err != nil {
+			if err := o.recordFailure(step.role, agent.GetName(), fmt.Errorf("deep dive on %q: %w", idea.Title, err)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		o.fireEvidence(step.role, response)
+		o.addRisks(step.role, response.Risks)
+		o.addPlans(step.role, response.Plans)
+		step.store(result, response.Content)
+
+		focus.Messages = append(focus.Messages, models.Message{
+			From:    string(step.role),
+			To:      "team",
+			Content: response.Content,
+			Type:    string(step.role),
+		})
+		o.addMessage(string(step.role), "team", response.Content, "deep_dive")
+		o.notify(fmt.Sprintf("  📣 [%s] %s", string(step.role), o.truncate(response.Content, 200)))
+	}
+
+	if !result.IsEmpty() {
+		idea.DeepDive = result
+	}
+	return nil
+}
+
+// topIdeaIndexes returns the indexes of the k highest-scored ideas, keeping
+// discussion order for ties, and the order used. When no idea is scored
+// (no moderator, or scoring failed) it ranks by activity instead: the ideas
+// revised and merged into most often, which drew the team's attention.
+func (o *ConfigurableOrchestrator) topIdeaIndexes(k int) ([]int, string) {
+	ideas := o.Discussion.Ideas
+	idx := make([]int, len(ideas))
+	for i := range idx {
+		idx[i] = i
+	}
+	order := models.DeepDiveByActivity
+	rank := func(i int) float64 { return float64(len(ideas[i].Revisions) + len(ideas[i].MergedFrom)) }
+	if slices.ContainsFunc(ideas, func(idea models.Idea) bool { return idea.Score > 0 }) {
+		order = models.DeepDiveByScore
+		rank = func(i int) float64 { return ideas[i].Score }
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		return rank(idx[a]) > rank(idx[b])
+	})
+	if len(idx) > k {
+		idx = idx[:k]
+	}
+	return idx, order
+}
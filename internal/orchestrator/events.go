@@ -0,0 +1,224 @@
+package orchestrator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yourusername/ai-agent-team/internal/agents"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// EventType identifies the kind of orchestrator event.
+type EventType string
+
+const (
+	EventPhaseStarted  EventType = "phase_started"
+	EventAgentStarted  EventType = "agent_started"
+	EventAgentChunk    EventType = "agent_chunk"
+	EventAgentFinished EventType = "agent_finished"
+	EventIdeaAdded     EventType = "idea_added"
+	EventIdeaScored    EventType = "idea_scored"
+	EventModelAssigned EventType = "model_assigned"
+	EventRoundSummary  EventType = "round_summary"
+	EventError         EventType = "error"
+	EventCompleted     EventType = "completed"
+)
+
+// Phase identifies a stage of the discussion.
+type Phase string
+
+const (
+	PhaseModelAssignment Phase = "model_assignment"
+	PhaseKickoff         Phase = "kickoff"
+	PhaseExploration     Phase = "exploration"
+	PhaseSynthesis       Phase = "synthesis"
+	PhaseConvergence     Phase = "convergence"
+	PhaseValidation      Phase = "validation"
+	PhaseDeepDive        Phase = "deep_dive"
+	PhaseTournament      Phase = "tournament"
+	PhaseSelection       Phase = "selection"
+	PhasePlanning        Phase = "planning"
+	PhaseSummary         Phase = "summary"
+	PhaseVisualization   Phase = "visualization"
+)
+
+// Label returns a human-readable name for the phase.
+func (p Phase) Label() string {
+	switch p {
+	case PhaseModelAssignment:
+		return "Assigning Models"
+	case PhaseKickoff:
+		return "Team Leader Kickoff"
+	case PhaseExploration:
+		return "Exploration & Ideation"
+	case PhaseSynthesis:
+		return "Leader Synthesis"
+	case PhaseConvergence:
+		return "Convergence Check"
+	case PhaseValidation:
+		return "Final Validation"
+	case PhaseDeepDive:
+		return "Deep Dive"
+	case PhaseTournament:
+		return "Tournament Ranking"
+	case PhaseSelection:
+		return "Final Selection"
+	case PhasePlanning:
+		return "Implementation Plan"
+	case PhaseSummary:
+		return "Executive Summary"
+	case PhaseVisualization:
+		return "Creating Idea Sheet"
+	}
+	return string(p)
+}
+
+// Event is a typed progress notification from the ConfigurableOrchestrator.
+// Which fields are set depends on Type:
+//   - PhaseStarted:  Phase, Round
+//   - AgentStarted:  Phase, Role
+//   - AgentChunk:    Role, Text (the streamed token)
+//   - AgentFinished: Phase, Role, Text (the full contribution)
+//   - IdeaAdded:     Role, Idea
+//   - IdeaScored:    Idea
+//   - ModelAssigned: Role, Model
+//   - RoundSummary:  Round, Summary
+//   - Error:         Phase, Role (empty for run-level failures), Text
+//   - Completed:     none
+type Event struct {
+	Type      EventType            `json:"type"`
+	Timestamp time.Time            `json:"timestamp"`
+	Phase     Phase                `json:"phase,omitempty"`
+	Round     int                  `json:"round,omitempty"`
+	Role      string               `json:"role,omitempty"`
+	Text      string               `json:"text,omitempty"`
+	Model     string               `json:"model,omitempty"`
+	Idea      *models.Idea         `json:"idea,omitempty"`
+	Summary   *models.RoundSummary `json:"summary,omitempty"`
+	Cause     string               `json:"cause,omitempty"` // agent Error events: why the call failed (models.Failure*)
+}
+
+// bus fans values out to subscribers. Handlers run synchronously on the
+// orchestrator goroutine, in subscription order.
+type bus[T any] struct {
+	mu       sync.Mutex
+	nextID   int
+	handlers map[int]func(T)
+	order    []int
+}
+
+// subscribe registers handler and returns a function that removes it
+func (b *bus[T]) subscribe(handler func(T)) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.handlers == nil {
+		b.handlers = make(map[int]func(T))
+	}
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+	b.order = append(b.order, id)
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+		for i, v := range b.order {
+			if v == id {
+				b.order = append(b.order[:i], b.order[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// publish delivers v to every subscriber
+func (b *bus[T]) publish(v T) {
+	b.mu.Lock()
+	handlers := make([]func(T), 0, len(b.order))
+	for _, id := range b.order {
+		handlers = append(handlers, b.handlers[id])
+	}
+	b.mu.Unlock()
+
+	for _, h := range handlers {
+		h(v)
+	}
+}
+
+// Subscribe registers a handler for all orchestrator events and returns a
+// function that removes it. Handlers must not block for long.
+func (o *ConfigurableOrchestrator) Subscribe(handler func(Event)) (unsubscribe func()) {
+	return o.events.subscribe(handler)
+}
+
+// emit stamps the event, publishes a snapshot at boundary events and
+// delivers it to every subscriber.
+func (o *ConfigurableOrchestrator) emit(ev Event) {
+	ev.Timestamp = time.Now()
+	if ev.Round == 0 && o.Discussion != nil {
+		ev.Round = o.Discussion.Round
+	}
+	if snapshotEvent(ev.Type) {
+		o.changed()
+	}
+	o.events.publish(ev)
+}
+
+// startPhase records the current phase and emits PhaseStarted.
+func (o *ConfigurableOrchestrator) startPhase(phase Phase) {
+	o.phase = phase
+	round := 0
+	if roundPhase(phase) {
+		round = o.Discussion.Round
+	}
+	o.metrics.beginPhase(phase, round)
+	o.updateMetrics()
+	o.emit(Event{Type: EventPhaseStarted, Phase: phase})
+}
+
+// emitError emits an Error event for an agent's failed call in the current phase.
+func (o *ConfigurableOrchestrator) emitError(role models.AgentRole, err error) {
+	o.emit(Event{Type: EventError, Phase: o.phase, Role: string(role), Text: err.Error(), Cause: failureCause(err)})
+}
+
+// emitIdea emits an IdeaAdded or IdeaScored event with a copy of the idea.
+func (o *ConfigurableOrchestrator) emitIdea(t EventType, role models.AgentRole, idea models.Idea) {
+	o.emit(Event{Type: t, Role: string(role), Idea: &idea})
+}
+
+// process runs one agent turn against the given discussion, emitting
+// AgentStarted, AgentChunk and AgentFinished (or Error) events around it.
+func (o *ConfigurableOrchestrator) process(role models.AgentRole, agent agents.Agent, d *models.Discussion, prompt string) (*models.AgentResponse, error) {
+	var response *models.AgentResponse
+	err := o.invoke(role, agent, func() (string, error) {
+		var err error
+		response, err = agent.Process(d, prompt)
+		if err != nil {
+			return "", err
+		}
+		return response.Content, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// invoke runs call, any request to agent besides Process, with the same
+// events and streaming as process. call returns the text of the
+// AgentFinished event.
+func (o *ConfigurableOrchestrator) invoke(role models.AgentRole, agent agents.Agent, call func() (string, error)) error {
+	o.emit(Event{Type: EventAgentStarted, Phase: o.phase, Role: string(role)})
+
+	unwire := o.wireAgent(role, agent)
+	text, err := call()
+	unwire()
+	if err != nil {
+		o.emitError(role, err)
+		return err
+	}
+
+	o.emit(Event{Type: EventAgentFinished, Phase: o.phase, Role: string(role), Text: text})
+	return nil
+}
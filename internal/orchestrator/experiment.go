@@ -0,0 +1,341 @@
+package orchestrator
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/ai-agent-team/internal/llm"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// experimentMatchThreshold is the token-set Jaccard similarity above which
+// ideas from two arms count as the same idea. It is lower than the dedup
+// threshold because separate teams phrase the same idea differently.
+const experimentMatchThreshold = 0.3
+
+// Experiment runs one topic under several team configurations or models at
+// the same time and compares the discussions: idea overlap, scores, final
+// picks, cost and duration.
+type Experiment struct {
+	Backend *llm.BackendConfig
+	Topic   string
+	Arms    []models.ExperimentArm
+
+	// Budget is shared by all arms. Its token and cost limits are split
+	// evenly between them, tightening each arm's own Config.Budget; its time
+	// limit applies to each arm whole, since the arms run side by side.
+	Budget models.Budget
+
+	// MaxParallel caps the arms running at once; 0 runs them all together.
+	MaxParallel int
+
+	// ID is the experiment's ID; a new UUID is generated when empty.
+	ID string
+
+	// FirecrawlKey is passed to every arm's orchestrator; see
+	// ConfigurableOrchestrator.FirecrawlKey.
+	FirecrawlKey string
+
+	// OnProgress receives each arm's log lines. Calls are serialized.
+	OnProgress func(arm, message string)
+
+	mu       sync.Mutex
+	result   *models.ExperimentResult
+	progress sync.Mutex
+}
+
+// NewExperiment creates an experiment on topic with the given arms
+func NewExperiment(cfg *llm.BackendConfig, topic string, arms ...models.ExperimentArm) *Experiment {
+	return &Experiment{Backend: cfg, Topic: topic, Arms: arms}
+}
+
+// Validate checks the experiment can run and names unnamed arms. Run calls it
+// first; call it directly to reject a bad experiment before running it in
+// the background.
+func (e *Experiment) Validate() error {
+	if e.Backend == nil {
+		return fmt.Errorf("experiment has no backend")
+	}
+	if strings.TrimSpace(e.Topic) == "" {
+		return fmt.Errorf("experiment has no topic")
+	}
+	if len(e.Arms) < 2 {
+		return fmt.Errorf("experiment needs at least 2 arms, got %d", len(e.Arms))
+	}
+	seen := make(map[string]bool)
+	for i := range e.Arms {
+		arm := &e.Arms[i]
+		if arm.Name == "" {
+			arm.Name = fmt.Sprintf("arm %d", i+1)
+		}
+		if seen[arm.Name] {
+			return fmt.Errorf("duplicate arm name %q", arm.Name)
+		}
+		seen[arm.Name] = true
+	}
+	return nil
+}
+
+// Run runs every arm's discussion and returns the comparison. An arm whose
+// discussion fails is reported as failed in the result; Run itself only
+// fails when the experiment is invalid.
+func (e *Experiment) Run() (*models.ExperimentResult, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+
+	res := &models.ExperimentResult{
+		ID:        e.ID,
+		Topic:     e.Topic,
+		Status:    "running",
+		StartTime: time.Now(),
+		Budget:    e.Budget,
+	}
+	for _, arm := range e.Arms {
+		res.Arms = append(res.Arms, models.ArmResult{Name: arm.Name, Status: "pending"})
+	}
+	e.mu.Lock()
+	e.result = res
+	e.mu.Unlock()
+
+	parallel := e.MaxParallel
+	if parallel <= 0 || parallel > len(e.Arms) {
+		parallel = len(e.Arms)
+	}
+	slots := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, arm := range e.Arms {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			e.runArm(i, arm)
+		}()
+	}
+	wg.Wait()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	compareArms(res)
+	res.EndTime = time.Now()
+	res.Status = "completed"
+	return copyExperimentResult(res), nil
+}
+
+// Result returns a copy of the experiment's current state, or nil before Run.
+// It is safe to call while the experiment runs.
+func (e *Experiment) Result() *models.ExperimentResult {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.result == nil {
+		return nil
+	}
+	return copyExperimentResult(e.result)
+}
+
+func copyExperimentResult(r *models.ExperimentResult) *models.ExperimentResult {
+	c := *r
+	c.Arms = append([]models.ArmResult(nil), r.Arms...)
+	c.Overlaps = append([]models.IdeaOverlap(nil), r.Overlaps...)
+	return &c
+}
+
+// armBudget is each arm's share of the experiment budget
+func (e *Experiment) armBudget() models.Budget {
+	n := len(e.Arms)
+	return models.Budget{
+		MaxTokens:   e.Budget.MaxTokens / n,
+		MaxCost:     e.Budget.MaxCost / float64(n),
+		MaxDuration: e.Budget.MaxDuration,
+	}
+}
+
+// armConfig copies the arm's team configuration so arms share no state.
+// Team memory is off so arms neither see each other's runs nor fill the
+// memory with copies of the same topic.
+func (e *Experiment) armConfig(arm models.ExperimentArm) *models.TeamConfig {
+	config := models.DefaultTeamConfig()
+	if arm.Config != nil {
+		copied := *arm.Config
+		config = &copied
+	}
+	config.AgentModels = maps.Clone(config.AgentModels)
+	config.TeamMemory = false
+	config.Budget = config.Budget.Min(e.armBudget())
+	return config
+}
+
+// runArm runs arm i's discussion and records its result
+func (e *Experiment) runArm(i int, arm models.ExperimentArm) {
+	backend := *e.Backend
+	if arm.Model != "" {
+		backend.Model = arm.Model
+	}
+	config := e.armConfig(arm)
+
+	orch := NewConfigurableOrchestrator(&backend, config)
+	orch.FirecrawlKey = e.FirecrawlKey
+	orch.OnProgress = func(message string) {
+		e.notify(arm.Name, message)
+	}
+	e.mu.Lock()
+	e.result.Arms[i].Status = "running"
+	e.result.Arms[i].Model = backend.Model
+	e.result.Arms[i].Agents = config.TeamSize()
+	e.mu.Unlock()
+
+	err := orch.StartDiscussion(e.Topic)
+	result := armResult(arm.Name, backend.Model, config.TeamSize(), orch.Discussion, err)
+	e.notify(arm.Name, fmt.Sprintf("🧪 Arm %s: %s", result.Status, describeArm(result)))
+
+	e.mu.Lock()
+	e.result.Arms[i] = result
+	e.mu.Unlock()
+}
+
+func (e *Experiment) notify(arm, message string) {
+	if e.OnProgress == nil {
+		return
+	}
+	e.progress.Lock()
+	defer e.progress.Unlock()
+	e.OnProgress(arm, message)
+}
+
+// describeArm summarizes an arm's outcome in one line
+func describeArm(a models.ArmResult) string {
+	if a.Error != "" {
+		return a.Error
+	}
+	final := "no final idea"
+	if a.FinalIdea != "" {
+		final = fmt.Sprintf("%q (%.1f)", a.FinalIdea, a.FinalScore)
+	}
+	return fmt.Sprintf("%d ideas, %s, $%.4f, %s", a.Ideas, final, a.Cost, a.Duration.Round(time.Second))
+}
+
+// armResult summarizes an arm's finished discussion
+func armResult(name, model string, agents int, d *models.Discussion, err error) models.ArmResult {
+	a := models.ArmResult{Name: name, Model: model, Agents: agents, Status: "failed"}
+	if err != nil {
+		a.Error = err.Error()
+	}
+	if d == nil {
+		return a
+	}
+	a.DiscussionID = d.ID
+	a.Discussion = d
+	if err == nil {
+		a.Status = d.Status
+	}
+	a.Rounds = d.Round
+	a.StopReason = d.StopReason
+	a.Ideas = len(d.Ideas)
+	a.Failures = len(d.Failures)
+
+	var sum float64
+	var validated int
+	for _, idea := range d.Ideas {
+		if !idea.Validated {
+			continue
+		}
+		sum += idea.Score
+		validated++
+		a.TopScore = max(a.TopScore, idea.Score)
+	}
+	if validated > 0 {
+		a.MeanScore = sum / float64(validated)
+	}
+	if d.FinalIdea != nil {
+		a.FinalIdea = d.FinalIdea.Title
+		a.FinalScore = d.FinalIdea.Score
+	}
+
+	if d.Budget != nil {
+		a.Cost = d.Budget.Used.Cost
+		a.Tokens = d.Budget.Used.Tokens
+		a.Calls = d.Budget.Used.Calls
+		a.Duration = d.Budget.Used.Duration
+	}
+	if !d.EndTime.IsZero() {
+		a.Duration = d.EndTime.Sub(d.StartTime)
+	}
+	return a
+}
+
+// compareArms measures each arm against the first and the completed arms
+// against each other.
+func compareArms(res *models.ExperimentResult) {
+	base := res.Arms[0]
+	for i := range res.Arms {
+		a := &res.Arms[i]
+		if base.Cost > 0 {
+			a.CostRatio = a.Cost / base.Cost
+		}
+		if i > 0 && a.Status == "completed" && base.Status == "completed" {
+			a.ScoreDelta = a.FinalScore - base.FinalScore
+		}
+	}
+
+	var done []models.ArmResult
+	for _, a := range res.Arms {
+		if a.Status == "completed" {
+			done = append(done, a)
+		}
+	}
+	res.Overlaps = nil
+	res.SamePick = len(done) >= 2
+	for i := range done {
+		for j := i + 1; j < len(done); j++ {
+			overlap := ideaOverlap(done[i], done[j])
+			res.SamePick = res.SamePick && overlap.SameFinal
+			res.Overlaps = append(res.Overlaps, overlap)
+		}
+	}
+}
+
+// ideaOverlap matches each idea of a with the most similar idea of b
+func ideaOverlap(a, b models.ArmResult) models.IdeaOverlap {
+	overlap := models.IdeaOverlap{A: a.Name, B: b.Name}
+	if a.Discussion == nil || b.Discussion == nil {
+		return overlap
+	}
+	ideasA, ideasB := a.Discussion.Ideas, b.Discussion.Ideas
+
+	tokensB := make([]map[string]bool, len(ideasB))
+	for i, idea := range ideasB {
+		tokensB[i] = ideaTokens(idea)
+	}
+	for _, idea := range ideasA {
+		tokens := ideaTokens(idea)
+		best, bestSim := -1, 0.0
+		for i := range ideasB {
+			if sim := jaccard(tokens, tokensB[i]); sim > bestSim {
+				best, bestSim = i, sim
+			}
+		}
+		if best >= 0 && bestSim >= experimentMatchThreshold {
+			overlap.Shared++
+			overlap.Matches = append(overlap.Matches, models.IdeaMatch{A: idea.Title, B: ideasB[best].Title, Similarity: bestSim})
+		}
+	}
+	sort.SliceStable(overlap.Matches, func(i, j int) bool { return overlap.Matches[i].Similarity > overlap.Matches[j].Similarity })
+	if smaller := min(len(ideasA), len(ideasB)); smaller > 0 {
+		overlap.Similarity = min(1, float64(overlap.Shared)/float64(smaller))
+	}
+
+	finalA, finalB := a.Discussion.FinalIdea, b.Discussion.FinalIdea
+	if finalA != nil && finalB != nil {
+		overlap.SameFinal = jaccard(ideaTokens(*finalA), ideaTokens(*finalB)) >= experimentMatchThreshold
+	}
+	return overlap
+}
@@ -0,0 +1,77 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/ai-agent-team/internal/llm"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// failureCause classifies why an agent's LLM call failed.
+func failureCause(err error) string {
+	if errors.Is(err, errBudgetExhausted) {
+		return models.FailureBudget
+	}
+
+	var apiErr *llm.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return models.FailureAuth
+		case http.StatusTooManyRequests, 529: // 529: Anthropic's "overloaded"
+			return models.FailureRateLimit
+		case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+			return models.FailureTimeout
+		}
+		return models.FailureAPI
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return models.FailureTimeout
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.Is(err, llm.ErrMalformedResponse) || errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return models.FailureParse
+	}
+	return models.FailureUnknown
+}
+
+// recordFailure records a failed agent contribution on the discussion as an
+// error message and an AgentFailure, and the discussion carries on without
+// it. With StrictFailures it returns the error that stops the discussion.
+func (o *ConfigurableOrchestrator) recordFailure(role models.AgentRole, name string, err error) error {
+	cause := failureCause(err)
+	log.Printf("Warning: %s contribution failed (%s): %v", name, cause, err)
+
+	id := o.addMessage(string(role), "team", fmt.Sprintf("%s could not contribute (%s).", name, cause), models.MessageError)
+	round := 0
+	if roundPhase(o.phase) {
+		round = o.Discussion.Round
+	}
+	o.Discussion.Failures = append(o.Discussion.Failures, models.AgentFailure{
+		Role:      string(role),
+		Agent:     name,
+		Phase:     string(o.phase),
+		Round:     round,
+		Cause:     cause,
+		Error:     err.Error(),
+		MessageID: id,
+		Time:      time.Now(),
+	})
+	o.notify(fmt.Sprintf("  ⚠️  %s failed (%s)", name, cause))
+
+	if o.Config.StrictFailures {
+		return fmt.Errorf("%s contribution failed (%s): %w", name, cause, err)
+	}
+	return nil
+}
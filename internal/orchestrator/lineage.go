@@ -0,0 +1,38 @@
+package orchestrator
+
+import "github.com/yourusername/ai-agent-team/internal/models"
+
+// linkLineage stamps a newly proposed idea with the current round and, when it
+// builds on an existing idea, carries that parent's version history forward.
+func (o *ConfigurableOrchestrator) linkLineage(idea *models.Idea) {
+	idea.Round = o.Discussion.Round
+	if len(idea.ParentIDs) == 0 {
+		return
+	}
+
+	parent := o.findIdea(idea.ParentIDs[0])
+	if parent == nil {
+		return
+	}
+
+	revisions := make([]models.IdeaRevision, 0, len(parent.Revisions)+1)
+	revisions = append(revisions, parent.Revisions...)
+	idea.Revisions = append(revisions, models.IdeaRevision{
+		IdeaID:      parent.ID,
+		Round:       parent.Round,
+		Title:       parent.Title,
+		Description: parent.Description,
+		CreatedBy:   parent.CreatedBy,
+		Score:       parent.Score,
+	})
+}
+
+// findIdea returns the discussion idea with the given ID, or nil.
+func (o *ConfigurableOrchestrator) findIdea(id string) *models.Idea {
+	for i := range o.Discussion.Ideas {
+		if o.Discussion.Ideas[i].ID == id {
+			return &o.Discussion.Ideas[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package orchestrator
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+const (
+	// maxRecalledMemories caps the past discussions injected into a new one.
+	maxRecalledMemories = 3
+	// memoryEmbeddingThreshold is the cosine similarity above which a past
+	// discussion counts as related when embeddings are available.
+	memoryEmbeddingThreshold = 0.75
+	// memoryKeywordThreshold is the share of the topic's words a past
+	// discussion must contain to count as related without embeddings.
+	memoryKeywordThreshold = 0.3
+)
+
+// memoryMu serializes reads and writes of the team memory file between
+// discussions running in the same process.
+var memoryMu sync.Mutex
+
+// memoryPath is the team memory file this orchestrator uses.
+func (o *ConfigurableOrchestrator) memoryPath() string {
+	if o.MemoryPath != "" {
+		return o.MemoryPath
+	}
+	return models.DefaultMemoryPath()
+}
+
+// recallMemories loads team memory and stores the past discussions related to
+// the topic on the discussion, where the leader and ideation agent see them.
+// Failures are logged and the discussion runs without memory.
+func (o *ConfigurableOrchestrator) recallMemories() {
+	if !o.Config.TeamMemory {
+		return
+	}
+	memoryMu.Lock()
+	mem, err := models.LoadTeamMemory(o.memoryPath())
+	memoryMu.Unlock()
+	if err != nil {
+		log.Printf("Warning: team memory unavailable: %v", err)
+		return
+	}
+
+	o.Discussion.Recalled = o.relatedMemories(mem.Entries, o.Discussion.Topic)
+	if len(o.Discussion.Recalled) == 0 {
+		return
+	}
+	var topics []string
+	for _, e := range o.Discussion.Recalled {
+		topics = append(topics, fmt.Sprintf("%q", e.Topic))
+	}
+	o.notify(fmt.Sprintf("🧠 Recalled %d related past discussion(s): %s", len(topics), strings.Join(topics, ", ")))
+}
+
+// relatedMemories ranks past discussions by similarity to topic: cosine
+// similarity of embeddings where the entry has one and the backend supports
+// embeddings, otherwise the share of topic words the entry mentions.
+func (o *ConfigurableOrchestrator) relatedMemories(entries []models.MemoryEntry, topic string) []models.MemoryEntry {
+	var topicVector []float64
+	if embedder := o.probeEmbedder(); embedder != nil {
+		if vecs, err := embedder.Embed([]string{topic}); err == nil && len(vecs) == 1 {
+			topicVector = vecs[0]
+		} else if err != nil {
+			log.Printf("Warning: topic embedding failed, recalling memories by keyword: %v", err)
+		}
+	}
+	topicTokens := textTokens(topic)
+
+	type match struct {
+		entry models.MemoryEntry
+		score float64
+	}
+	var matches []match
+	for _, e := range entries {
+		if e.DiscussionID == o.Discussion.ID {
+			continue
+		}
+		if len(topicVector) > 0 && len(e.Vector) == len(topicVector) {
+			if sim := cosine(topicVector, e.Vector); sim >= memoryEmbeddingThreshold {
+				matches = append(matches, match{e, sim})
+			}
+			continue
+		}
+		if cov := coverage(topicTokens, textTokens(e.Text())); cov >= memoryKeywordThreshold {
+			matches = append(matches, match{e, cov})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	var related []models.MemoryEntry
+	for i, m := range matches {
+		if i == maxRecalledMemories {
+			break
+		}
+		related = append(related, m.entry)
+	}
+	return related
+}
+
+// coverage returns the share of a's tokens that also appear in b.
+func coverage(a, b map[string]bool) float64 {
+	if len(a) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range a {
+		if b[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a))
+}
+
+// rememberDiscussion records the finished discussion in team memory.
+// Failures are logged; the discussion result is unaffected.
+func (o *ConfigurableOrchestrator) rememberDiscussion() {
+	if !o.Config.TeamMemory {
+		return
+	}
+	entry := models.NewMemoryEntry(o.Discussion)
+	if embedder := o.probeEmbedder(); embedder != nil {
+		if vecs, err := embedder.Embed([]string{entry.Text()}); err == nil && len(vecs) == 1 {
+			entry.Vector = vecs[0]
+		}
+	}
+
+	memoryMu.Lock()
+	defer memoryMu.Unlock()
+	path := o.memoryPath()
+	mem, err := models.LoadTeamMemory(path)
+	if err != nil {
+		log.Printf("Warning: could not update team memory: %v", err)
+		return
+	}
+	mem.Remember(entry)
+	if err := mem.Save(path); err != nil {
+		log.Printf("Warning: could not update team memory: %v", err)
+		return
+	}
+	o.notify(fmt.Sprintf("🧠 Remembered this discussion in team memory (%d entries)", len(mem.Entries)))
+}
+
+// warnIfRejectedBefore notes when a new idea resembles one the team rejected
+// in a recalled past discussion.
+func (o *ConfigurableOrchestrator) warnIfRejectedBefore(idea models.Idea) {
+	tokens := ideaTokens(idea)
+	for _, e := range o.Discussion.Recalled {
+		for _, r := range e.Rejected {
+			if jaccard(tokens, textTokens(r.Title+"\n"+r.Description)) >= lexicalDupThreshold {
+				o.notify(fmt.Sprintf("    🧠 %q resembles %q, rejected in %q (%s)", idea.Title, r.Title, e.Topic, r.Reason))
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package orchestrator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+	"github.com/yourusername/ai-agent-team/internal/report"
+)
+
+// metricsRecorder builds the run metrics as phases start and agent clients
+// finish LLM calls. Clients record into it from whichever goroutine runs the call.
+type metricsRecorder struct {
+	mu       sync.Mutex
+	metrics  models.RunMetrics
+	failures map[string]int // consecutive failed calls per role in the running phase
+}
+
+func newMetricsRecorder() *metricsRecorder {
+	return &metricsRecorder{failures: make(map[string]int)}
+}
+
+// reset clears all recorded metrics
+func (r *metricsRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = models.RunMetrics{}
+	r.failures = make(map[string]int)
+}
+
+// current returns the running phase, or nil before the first phase
+func (r *metricsRecorder) current() *models.PhaseMetrics {
+	if len(r.metrics.Phases) == 0 {
+		return nil
+	}
+	return &r.metrics.Phases[len(r.metrics.Phases)-1]
+}
+
+// beginPhase closes the running phase and opens a new one. round is 0 for
+// phases outside the round loop.
+func (r *metricsRecorder) beginPhase(phase Phase, round int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if p := r.current(); p != nil && p.Outcome == "" {
+		p.Duration = now.Sub(p.Start)
+		p.Outcome = models.OutcomeOK
+	}
+	r.metrics.Phases = append(r.metrics.Phases, models.PhaseMetrics{
+		Phase: string(phase),
+		Label: phase.Label(),
+		Round: round,
+		Start: now,
+	})
+	r.failures = make(map[string]int)
+}
+
+// finish closes the running phase with outcome
+func (r *metricsRecorder) finish(outcome string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p := r.current(); p != nil && p.Outcome == "" {
+		p.Duration = time.Since(p.Start)
+		p.Outcome = outcome
+	}
+}
+
+// record adds a finished call to the running phase. A call that follows
+// failed calls by the same role in the phase counts them as retries.
+func (r *metricsRecorder) record(call models.CallMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	call.Retries = r.failures[call.Role]
+	if call.Outcome == models.OutcomeOK {
+		delete(r.failures, call.Role)
+	} else {
+		r.failures[call.Role]++
+	}
+
+	if p := r.current(); p != nil {
+		call.Phase, call.Round = p.Phase, p.Round
+		p.Calls++
+		p.Tokens += call.InputTokens + call.OutputTokens
+		p.Cost += call.Cost
+		if call.Outcome != models.OutcomeOK {
+			p.Errors++
+		}
+	}
+	r.metrics.Calls = append(r.metrics.Calls, call)
+}
+
+// retrying counts role's next call as a retry of an answer that was rejected
+// rather than one that failed
+func (r *metricsRecorder) retrying(role models.AgentRole) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures[string(role)]++
+}
+
+// snapshot returns a copy of the metrics, timing the running phase up to now
+func (r *metricsRecorder) snapshot() *models.RunMetrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.metrics.Clone()
+	if n := len(m.Phases); n > 0 && m.Phases[n-1].Outcome == "" {
+		m.Phases[n-1].Duration = time.Since(m.Phases[n-1].Start)
+	}
+	return m
+}
+
+// updateMetrics refreshes Discussion.Metrics from the recorder
+func (o *ConfigurableOrchestrator) updateMetrics() {
+	if o.Discussion != nil {
+		o.Discussion.Metrics = o.metrics.snapshot()
+	}
+}
+
+// roundPhase reports whether phase runs once per round
+func roundPhase(phase Phase) bool {
+	switch phase {
+	case PhaseExploration, PhaseSynthesis, PhaseConvergence:
+		return true
+	}
+	return false
+}
+
+// appendMetrics injects the run metrics appendix into the idea sheet.
+func (o *ConfigurableOrchestrator) appendMetrics() {
+	o.updateMetrics()
+	metricsHTML := report.RenderMetricsHTML(o.Discussion)
+	if metricsHTML == "" {
+		return
+	}
+
+	if o.injectReportSection(metricsHTML) {
+		o.notify("  ✅ Run metrics appendix injected into idea sheet")
+		return
+	}
+	o.addMessage(string(models.RoleUICreator), "team", metricsHTML, "metrics_section")
+}
@@ -0,0 +1,203 @@
+package orchestrator
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/ai-agent-team/internal/agents"
+	"github.com/yourusername/ai-agent-team/internal/llm"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// modelRequirement is what a role needs from its model.
+type modelRequirement struct {
+	tools     bool // the role calls tools
+	minOutput int  // longest response the role asks for, in tokens
+}
+
+// roleRequirement returns what role needs from its model: tool calling for
+// the researcher and custom roles with tools, long output for the UI creator.
+func (o *ConfigurableOrchestrator) roleRequirement(role models.AgentRole) modelRequirement {
+	switch role {
+	case models.RoleResearcher:
+		return modelRequirement{tools: true}
+	case models.RoleUICreator:
+		return modelRequirement{minOutput: agents.IdeaSheetMaxTokens}
+	}
+	for _, cr := range o.Config.CustomRoles {
+		if cr.Role == role && len(cr.Tools) > 0 {
+			return modelRequirement{tools: true}
+		}
+	}
+	return modelRequirement{}
+}
+
+// matchesModel reports whether model matches pattern, an exact model ID or a
+// glob such as "claude-sonnet-*", ignoring case and provider prefixes.
+func matchesModel(pattern, model string) bool {
+	ok, err := path.Match(llm.BareModelID(pattern), llm.BareModelID(model))
+	return err == nil && ok
+}
+
+func matchesAnyModel(patterns []string, model string) bool {
+	for _, p := range patterns {
+		if matchesModel(p, model) {
+			return true
+		}
+	}
+	return false
+}
+
+// modelRejection returns why model cannot serve role under the model policy
+// and the role's requirements, or "" if it can.
+func (o *ConfigurableOrchestrator) modelRejection(role models.AgentRole, model string) string {
+	policy := o.Config.ModelPolicy
+	req := o.roleRequirement(role)
+	caps := llm.CapabilitiesOf(model)
+	switch {
+	case !caps.Chat:
+		return "not a chat model"
+	case len(policy.Allow) > 0 && !matchesAnyModel(policy.Allow, model):
+		return "not on the allow list"
+	case matchesAnyModel(policy.Deny, model):
+		return "on the deny list"
+	case req.tools && !caps.Tools:
+		return "no tool calling"
+	case caps.MaxOutputTokens < req.minOutput:
+		return fmt.Sprintf("output limit %d tokens, needs %d", caps.MaxOutputTokens, req.minOutput)
+	}
+	if ceiling := policy.PriceCeiling(role); ceiling > 0 {
+		if price := llm.PriceFor(model).Output; price > ceiling {
+			return fmt.Sprintf("$%.2f per million output tokens, over the $%.2f ceiling", price, ceiling)
+		}
+	}
+	return ""
+}
+
+// policyModel picks role's model from available: the first of the role's
+// preferences that a candidate matches (the shortest matching ID when a glob
+// matches several), else the default model, else the candidate with the
+// lowest output price, so a role the default cannot serve does not drift to
+// the most expensive model. Ties go to the lower ID, so the same inputs
+// always give the same model. It returns "" when no available model
+// satisfies the policy.
+func (o *ConfigurableOrchestrator) policyModel(role models.AgentRole, available []string) (model, reason string) {
+	var candidates []string
+	for _, m := range available {
+		if o.modelRejection(role, m) == "" {
+			candidates = append(candidates, m)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", ""
+	}
+
+	for _, pattern := range o.Config.ModelPolicy.Prefer[role] {
+		best := ""
+		for _, m := range candidates {
+			if matchesModel(pattern, m) && (best == "" || len(m) < len(best)) {
+				best = m
+			}
+		}
+		if best != "" {
+			return best, "preferred"
+		}
+	}
+
+	for _, m := range candidates {
+		if m == o.BackendConfig.Model {
+			return m, "default"
+		}
+	}
+
+	best := candidates[0]
+	for _, m := range candidates[1:] {
+		if llm.PriceFor(m).Output < llm.PriceFor(best).Output {
+			best = m
+		}
+	}
+	why := o.modelRejection(role, o.BackendConfig.Model)
+	if why == "" {
+		why = "not available"
+	}
+	return best, fmt.Sprintf("cheapest suitable model; default %s: %s", o.BackendConfig.Model, why)
+}
+
+// policyCandidates returns the models policy assignment chooses from, sorted
+// and without duplicates: the backend's model list, or the default model and
+// the preferred model IDs when the list is unavailable.
+func (o *ConfigurableOrchestrator) policyCandidates() []string {
+	var ids []string
+	list, err := llm.ListModels(o.BackendConfig)
+	if err == nil && len(list) > 0 {
+		for _, m := range list {
+			ids = append(ids, m.ID)
+		}
+	} else {
+		if err != nil {
+			o.notify(fmt.Sprintf("  ⚠️  Could not list models: %s (choosing from the default and preferred models)", err))
+		}
+		ids = append(ids, o.BackendConfig.Model)
+		for _, prefs := range o.Config.ModelPolicy.Prefer {
+			for _, p := range prefs {
+				if !strings.ContainsAny(p, "*?[") {
+					ids = append(ids, p)
+				}
+			}
+		}
+	}
+
+	sort.Strings(ids)
+	unique := ids[:0]
+	for i, id := range ids {
+		if id != "" && (i == 0 || id != ids[i-1]) {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// runPolicyModelAssignment assigns each agent a model by the model policy.
+// Models set in Config.AgentModels before the discussion are kept. An agent
+// no available model suits keeps its current model.
+func (o *ConfigurableOrchestrator) runPolicyModelAssignment() error {
+	available := o.policyCandidates()
+
+	roles := make([]models.AgentRole, 0, len(o.Agents))
+	for role := range o.Agents {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i] < roles[j] })
+
+	for _, role := range roles {
+		current := o.Agents[role].GetModel()
+		if configured := o.Config.AgentModels[role]; configured != "" {
+			o.notify(fmt.Sprintf("  🔧 [%s] → %s (configured)", role, configured))
+			continue
+		}
+
+		model, reason := o.policyModel(role, available)
+		if model == "" {
+			why := o.modelRejection(role, current)
+			if why == "" {
+				why = "not in the available list"
+			}
+			o.notify(fmt.Sprintf("  ⚠️  [%s] no available model satisfies the policy; keeping %s (%s)", role, current, why))
+			continue
+		}
+		if model != current {
+			if err := o.reinitAgent(role, model); err != nil {
+				log.Printf("Warning: failed to reassign %s to model %s: %v", role, model, err)
+				continue
+			}
+		}
+		o.emit(Event{Type: EventModelAssigned, Role: string(role), Model: model})
+		o.notify(fmt.Sprintf("  🔧 [%s] → %s (%s)", role, model, reason))
+	}
+
+	o.notify("  ✅ Model assignments complete")
+	return nil
+}
@@ -0,0 +1,36 @@
+package orchestrator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourusername/ai-agent-team/internal/llm"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// TestPolicyModelFallbackIsCheapest checks that with no price ceiling a role
+// the default model cannot serve gets the cheapest suitable model, not the
+// most expensive one.
+func TestPolicyModelFallbackIsCheapest(t *testing.T) {
+	available := []string{"claude-3-5-sonnet-latest", "claude-opus-4-1", "claude-sonnet-4-0", "gpt-4.1-nano", "gpt-4o", "local-llama"}
+	tests := []struct {
+		defaultModel string
+		role         models.AgentRole
+		want         string
+	}{
+		{"local-llama", models.RoleResearcher, "gpt-4.1-nano"},             // unknown model: no tool calling
+		{"local-llama", models.RoleUICreator, "gpt-4.1-nano"},              // unknown model: 4096 output tokens
+		{"claude-3-5-sonnet-latest", models.RoleUICreator, "gpt-4.1-nano"}, // 8192 output tokens
+		{"claude-3-5-sonnet-latest", models.RoleIdeation, "claude-3-5-sonnet-latest"},
+	}
+	for _, tt := range tests {
+		o := NewConfigurableOrchestrator(&llm.BackendConfig{Backend: "openai", APIKey: "test", Model: tt.defaultModel}, models.DefaultTeamConfig())
+		got, reason := o.policyModel(tt.role, available)
+		if got != tt.want {
+			t.Errorf("%s with default %s: got %s (%s), want %s", tt.role, tt.defaultModel, got, reason, tt.want)
+		}
+		if got != tt.defaultModel && !strings.Contains(reason, tt.defaultModel) {
+			t.Errorf("%s with default %s: reason %q does not say why the default was passed over", tt.role, tt.defaultModel, reason)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,10 +21,12 @@ type ConfigurableOrchestrator struct {
 	Config        *models.TeamConfig
 	BackendConfig *llm.BackendConfig
 	Agents        map[models.AgentRole]agents.Agent
-	Discussion    *models.Discussion
-	OnProgress    func(message string)
-	// OnChunk is called for each streaming token: role + chunk text.
-	OnChunk func(role string, chunk string)
+	// Discussion is the running or latest discussion. While it runs, only the
+	// goroutine running it may touch it; other goroutines use Snapshot.
+	Discussion *models.Discussion
+	// OnProgress receives human-readable log lines. Frontends that need to
+	// track state should use Subscribe and the typed Event stream instead.
+	OnProgress func(message string)
 	// OnEvidence is called when the researcher returns structured search results.
 	// role is the agent role string, results is []tools.SearchResult as []interface{}.
 	OnEvidence func(role string, results []interface{})
@@ -31,6 +34,34 @@ type ConfigurableOrchestrator struct {
 	// FirecrawlKey is the Firecrawl API key for the researcher agent's web search.
 	// If empty, falls back to the FIRECRAWL_API_KEY environment variable.
 	FirecrawlKey string
+
+	// DiscussionID is the ID given to the next discussion; a new UUID is
+	// generated when empty.
+	DiscussionID string
+
+	// Seed, when set, makes the next discussion a drill-down of an earlier
+	// one: agents see the parent's final idea, evidence and open questions.
+	Seed *models.DiscussionSeed
+
+	// MemoryPath is the team memory file used when Config.TeamMemory is set.
+	// If empty, models.DefaultMemoryPath() is used.
+	MemoryPath string
+
+	events   bus[Event]
+	watchers bus[*models.Discussion]
+
+	// snapshot is the latest published copy of Discussion, the only view of
+	// it other goroutines may read while a discussion runs
+	snapshotMu sync.RWMutex
+	snapshot   *models.Discussion
+
+	phase   Phase            // phase currently running, attached to agent events
+	deduper *ideaDeduper     // created on first use when Config.DedupIdeas is set
+	usage   *usageMeter      // estimated LLM spend of every agent client
+	metrics *metricsRecorder // timing and spend of every phase and LLM call
+
+	lastScores map[string]float64 // interim moderator scores from the previous round (adaptive mode)
+	questions  []models.Message   // directed questions waiting for a reply this round
 }
 
 // NewConfigurableOrchestrator creates a new orchestrator with custom team config.
@@ -47,6 +78,8 @@ func NewConfigurableOrchestrator(cfg *llm.BackendConfig, config *models.TeamConf
 		Config:        config,
 		BackendConfig: cfg,
 		Agents:        make(map[models.AgentRole]agents.Agent),
+		usage:         newUsageMeter(),
+		metrics:       newMetricsRecorder(),
 	}
 
 	orch.initAgents()
@@ -55,60 +88,38 @@ func NewConfigurableOrchestrator(cfg *llm.BackendConfig, config *models.TeamConf
 
 // initAgents creates agent instances using per-agent model assignments.
 func (o *ConfigurableOrchestrator) initAgents() {
-	type agentEntry struct {
-		role    models.AgentRole
-		include bool
-		create  func(llm.Client) agents.Agent
-	}
-
-	entries := []agentEntry{
-		{models.RoleTeamLeader, o.Config.IncludeTeamLeader, func(c llm.Client) agents.Agent { return agents.NewTeamLeaderAgent(c) }},
-		{models.RoleIdeation, o.Config.IncludeIdeation, func(c llm.Client) agents.Agent { return agents.NewIdeationAgent(c) }},
-		{models.RoleModerator, o.Config.IncludeModerator, func(c llm.Client) agents.Agent { return agents.NewModeratorAgent(c) }},
-		{models.RoleResearcher, o.Config.IncludeResearcher, func(c llm.Client) agents.Agent { return agents.NewResearcherAgent(c) }},
-		{models.RoleCritic, o.Config.IncludeCritic, func(c llm.Client) agents.Agent { return agents.NewCriticAgent(c) }},
-		{models.RoleImplementer, o.Config.IncludeImplementer, func(c llm.Client) agents.Agent { return agents.NewImplementerAgent(c) }},
-		{models.RoleUICreator, o.Config.IncludeUICreator, func(c llm.Client) agents.Agent { return agents.NewUICreatorAgent(c) }},
-	}
-
-	for _, e := range entries {
-		if !e.include {
+	for _, role := range o.Config.GetActiveAgentRoles() {
+		create, ok := o.factory(role)
+		if !ok {
+			log.Printf("Warning: no agent registered for role %s (skipping)", role)
 			continue
 		}
-		model := o.Config.AgentModels[e.role]
+		model := o.Config.AgentModels[role]
 		if model == "" {
 			model = o.BackendConfig.Model
 		}
 
 		client, err := llmfactory.NewClientWithModel(o.BackendConfig, model)
 		if err != nil {
-			log.Printf("Warning: failed to create client for %s with model %s: %v (using default)", e.role, model, err)
+			log.Printf("Warning: failed to create client for %s with model %s: %v (using default)", role, model, err)
 			client, _ = llmfactory.NewClient(o.BackendConfig)
 			model = o.BackendConfig.Model
 		}
 
-		agent := e.create(client)
-		// Set the model name on the agent's BaseAgent
-		if ba, ok := getBaseAgent(agent); ok {
-			ba.Model = model
+		agent := create(o.meterClient(role, model, client))
+		if h, ok := agent.(agents.Hooks); ok {
+			h.SetModel(model)
 		}
-		o.Agents[e.role] = agent
+		if s, ok := agent.(agents.CriteriaScorer); ok {
+			s.SetCriteria(o.Config.ScoringCriteria())
+		}
+		o.Agents[role] = agent
 	}
 }
 
 // reinitAgent recreates a single agent with a new model.
 func (o *ConfigurableOrchestrator) reinitAgent(role models.AgentRole, model string) error {
-	creators := map[models.AgentRole]func(llm.Client) agents.Agent{
-		models.RoleTeamLeader:  func(c llm.Client) agents.Agent { return agents.NewTeamLeaderAgent(c) },
-		models.RoleIdeation:    func(c llm.Client) agents.Agent { return agents.NewIdeationAgent(c) },
-		models.RoleModerator:   func(c llm.Client) agents.Agent { return agents.NewModeratorAgent(c) },
-		models.RoleResearcher:  func(c llm.Client) agents.Agent { return agents.NewResearcherAgent(c) },
-		models.RoleCritic:      func(c llm.Client) agents.Agent { return agents.NewCriticAgent(c) },
-		models.RoleImplementer: func(c llm.Client) agents.Agent { return agents.NewImplementerAgent(c) },
-		models.RoleUICreator:   func(c llm.Client) agents.Agent { return agents.NewUICreatorAgent(c) },
-	}
-
-	creator, ok := creators[role]
+	create, ok := o.factory(role)
 	if !ok {
 		return fmt.Errorf("unknown role: %s", role)
 	}
@@ -118,69 +129,228 @@ func (o *ConfigurableOrchestrator) reinitAgent(role models.AgentRole, model stri
 		return fmt.Errorf("creating client for %s model %s: %w", role, model, err)
 	}
 
-	agent := creator(client)
-	if ba, ok := getBaseAgent(agent); ok {
-		ba.Model = model
+	agent := create(o.meterClient(role, model, client))
+	if h, ok := agent.(agents.Hooks); ok {
+		h.SetModel(model)
+	}
+	if s, ok := agent.(agents.CriteriaScorer); ok {
+		s.SetCriteria(o.Config.ScoringCriteria())
 	}
 	o.Agents[role] = agent
 	o.Config.AgentModels[role] = model
 	return nil
 }
 
-// getBaseAgent extracts the embedded *BaseAgent from any agent via the common
-// concrete types. Returns false if the agent type is not recognized.
-func getBaseAgent(a agents.Agent) (*agents.BaseAgent, bool) {
-	switch v := a.(type) {
-	case *agents.TeamLeaderAgent:
-		return v.BaseAgent, true
-	case *agents.IdeationAgent:
-		return v.BaseAgent, true
-	case *agents.ModeratorAgent:
-		return v.BaseAgent, true
-	case *agents.ResearcherAgent:
-		return v.BaseAgent, true
-	case *agents.CriticAgent:
-		return v.BaseAgent, true
-	case *agents.ImplementerAgent:
-		return v.BaseAgent, true
-	case *agents.UICreatorAgent:
-		return v.BaseAgent, true
-	}
-	return nil, false
+// factory returns the constructor for a role: custom role definitions take
+// precedence, then the agents registry.
+func (o *ConfigurableOrchestrator) factory(role models.AgentRole) (agents.Factory, bool) {
+	if def, custom := o.Config.CustomRole(role); custom {
+		return func(c llm.Client) agents.Agent { return agents.NewCustomAgent(c, def) }, true
+	}
+	return agents.Lookup(role)
+}
+
+// phaseTurn is one extra agent's turn in a phase, with its task prompt.
+type phaseTurn struct {
+	role   models.AgentRole
+	prompt string
+}
+
+// phaseTurns returns the turns of team agents outside the built-in sequence
+// (custom and registered roles) that join the given phase, in team order.
+func (o *ConfigurableOrchestrator) phaseTurns(phase string) []phaseTurn {
+	var turns []phaseTurn
+	for _, role := range o.Config.GetActiveAgentRoles() {
+		p, ok := o.Agents[role].(agents.PhaseParticipant)
+		if !ok {
+			continue
+		}
+		if prompt, ok := p.PhasePrompt(phase); ok {
+			turns = append(turns, phaseTurn{role: role, prompt: prompt})
+		}
+	}
+	return turns
 }
 
 // StartDiscussion initiates a multi-round discussion
 func (o *ConfigurableOrchestrator) StartDiscussion(topic string) error {
-	o.Discussion = &models.Discussion{
-		ID:        uuid.New().String(),
-		Topic:     topic,
-		StartTime: time.Now(),
-		Messages:  []models.Message{},
-		Ideas:     []models.Idea{},
-		Status:    "running",
-		Round:     0,
-		MaxRounds: o.Config.MaxRounds,
+	d := &models.Discussion{
+		Topic:    topic,
+		Messages: []models.Message{},
+		Ideas:    []models.Idea{},
+		Criteria: o.Config.ScoringCriteria(),
+		Seed:     o.Seed,
+	}
+	if o.Seed != nil {
+		d.ParentID = o.Seed.ParentID
 	}
+	o.begin(d)
 
 	teamSize := o.Config.TeamSize()
 	o.notify(fmt.Sprintf("🎯 Starting discussion with %d agents on: %s", teamSize, topic))
-	o.notify(fmt.Sprintf("📊 Configuration: %d rounds, deep dive: %v", o.Config.MaxRounds, o.Config.DeepDive))
+	if seed := o.Discussion.Seed; seed != nil {
+		o.notify(fmt.Sprintf("🔎 Drill-down (level %d) of %q, building on: %s", seed.Depth, seed.ParentTopic, seed.Idea.Title))
+	}
+	o.recallMemories()
+	return o.run(1)
+}
+
+// ResumeDiscussion continues a fork made by models.ForkDiscussion with this
+// orchestrator's team and models, from the round after the fork point. Any
+// guidance is added to the discussion as human direction for the team.
+// The source discussion is left untouched: it may be a shared snapshot, so
+// the caller owning it records the fork in its ForkIDs.
+func (o *ConfigurableOrchestrator) ResumeDiscussion(fork *models.Discussion, guidance string) error {
+	if fork.ForkOf == "" {
+		return fmt.Errorf("discussion is not a fork")
+	}
+	fork.Criteria = o.Config.ScoringCriteria()
+	o.begin(fork)
+
+	o.notify(fmt.Sprintf("🍴 Forked from %s after round %d with %d agents on: %s", fork.ForkOf, fork.ForkRound, o.Config.TeamSize(), fork.Topic))
+	if guidance = strings.TrimSpace(guidance); guidance != "" {
+		o.addMessage("human", "team", guidance, "guidance")
+		o.notify(fmt.Sprintf("  🧭 Guidance: %s", o.truncate(guidance, 200)))
+	}
+	return o.run(fork.ForkRound + 1)
+}
+
+// begin makes d the current discussion and resets per-discussion state.
+func (o *ConfigurableOrchestrator) begin(d *models.Discussion) {
+	_, maxRounds := o.Config.RoundLimits()
+	d.ID = o.DiscussionID
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	d.StartTime = time.Now()
+	d.EndTime = time.Time{}
+	d.Status = "running"
+	d.MaxRounds = maxRounds
+	d.StopReason = ""
+	d.FinalIdea = nil
+	d.Tournament = nil
+	d.Budget = &models.BudgetStatus{
+		Limits:      o.Config.Budget,
+		AgentLimits: o.Config.AgentBudget,
+		Level:       models.BudgetOK,
+	}
+	o.Discussion = d
+	o.DiscussionID, o.Seed = "", nil
+	o.lastScores = nil
+	o.usage.reset(o.Config.Budget, d.StartTime)
+	o.metrics.reset()
+	o.changed()
+}
 
-	// Phase 0: Model Assignment (team leader selects models for agents)
-	if err := o.runModelAssignment(); err != nil {
-		// Non-fatal: fall back to default model for all agents
-		log.Printf("Model assignment skipped: %v", err)
+// run drives the current discussion from startRound (1 for a new
+// discussion) to completion and records the outcome.
+func (o *ConfigurableOrchestrator) run(startRound int) error {
+	minRounds, maxRounds := o.Config.RoundLimits()
+	if o.Config.AdaptiveRounds {
+		o.notify(fmt.Sprintf("📊 Configuration: %d-%d rounds (adaptive), deep dive: %v", minRounds, maxRounds, o.Config.DeepDive))
+	} else {
+		o.notify(fmt.Sprintf("📊 Configuration: %d rounds, deep dive: %v", o.Config.MaxRounds, o.Config.DeepDive))
+	}
+	if !o.Config.Budget.IsZero() || !o.Config.AgentBudget.IsZero() {
+		o.notify(fmt.Sprintf("💸 Budget: %s per discussion, %s per agent", formatBudget(o.Config.Budget), formatBudget(o.Config.AgentBudget)))
 	}
 
-	// Phase 1: Kickoff
-	if err := o.runKickoff(); err != nil {
-		return fmt.Errorf("kickoff failed: %w", err)
+	// Announce the initial model for every agent (may change after assignment)
+	for role, agent := range o.Agents {
+		o.emit(Event{Type: EventModelAssigned, Role: string(role), Model: agent.GetModel()})
 	}
 
-	// Phase 2: Multi-round exploration
-	for round := 1; round <= o.Config.MaxRounds; round++ {
+	err := o.runPhases(startRound)
+	o.updateBudget()
+	if err != nil {
+		o.metrics.finish(models.OutcomeFailed)
+		o.updateMetrics()
+		o.Discussion.Status = "failed"
+		o.emit(Event{Type: EventError, Phase: o.phase, Text: err.Error()})
+		return err
+	}
+
+	o.metrics.finish(models.OutcomeOK)
+	o.updateMetrics()
+	o.Discussion.EndTime = time.Now()
+	o.Discussion.Status = "completed"
+	o.rememberDiscussion()
+	if n := len(o.Discussion.Failures); n > 0 {
+		o.notify(fmt.Sprintf("\n⚠️  Discussion completed with %d failed agent contribution(s): %s", n, o.Discussion.FailureSummary()))
+	} else {
+		o.notify("\n✅ Discussion completed successfully!")
+	}
+	o.emit(Event{Type: EventCompleted})
+
+	return nil
+}
+
+// checkpoint records the discussion state at the end of round so it can be
+// forked there later.
+func (o *ConfigurableOrchestrator) checkpoint(round int) {
+	o.Discussion.Checkpoints = append(o.Discussion.Checkpoints, models.Checkpoint{
+		Round:        round,
+		MessageCount: len(o.Discussion.Messages),
+		Ideas:        models.CloneIdeas(o.Discussion.Ideas),
+	})
+}
+
+// StartDrillDown runs a follow-up discussion seeded with parent's final
+// idea, pros and cons, evidence and open questions. The child records its
+// ParentID; parent is only read, so the caller owning it records the child
+// in its ChildIDs. An empty topic asks how to realise the parent's final idea.
+func (o *ConfigurableOrchestrator) StartDrillDown(parent *models.Discussion, topic string) error {
+	seed, err := models.NewDrillDownSeed(parent)
+	if err != nil {
+		return fmt.Errorf("drill-down: %w", err)
+	}
+	if topic == "" {
+		topic = seed.DefaultTopic()
+	}
+	if o.DiscussionID == "" {
+		o.DiscussionID = uuid.New().String()
+	}
+	o.Seed = seed
+	return o.StartDiscussion(topic)
+}
+
+// runPhases drives the discussion from model assignment through the concept
+// map. A resumed fork (startRound > 1) skips model assignment and kickoff.
+func (o *ConfigurableOrchestrator) runPhases(startRound int) error {
+	if startRound <= 1 {
+		// Phase 0: Model Assignment (by policy, or the team leader in LLM mode)
+		// Agents keep the default model when assignment fails; only a
+		// strict failure stops the discussion
+		if err := o.runModelAssignment(); err != nil {
+			return fmt.Errorf("model assignment failed: %w", err)
+		}
+
+		// Phase 1: Kickoff
+		if err := o.runKickoff(); err != nil {
+			return fmt.Errorf("kickoff failed: %w", err)
+		}
+		o.checkpoint(0)
+	}
+
+	// Phase 2: Multi-round exploration, stopping early on convergence in adaptive mode
+	minRounds, maxRounds := o.Config.RoundLimits()
+	o.Discussion.StopReason = models.StopReasonMaxRounds
+	if o.Config.AdaptiveRounds {
+		o.Discussion.StopReason = models.StopReasonRoundCap
+	}
+	for round := startRound; round <= maxRounds; round++ {
+		// Shorten the discussion once the budget is critical
+		if round > startRound && !o.budgetAllows(fmt.Sprintf("rounds %d-%d", round, maxRounds), models.BudgetCritical) {
+			o.Discussion.StopReason = models.StopReasonBudget
+			break
+		}
 		o.Discussion.Round = round
-		o.notify(fmt.Sprintf("\n🔄 Round %d of %d", round, o.Config.MaxRounds))
+		if o.Config.AdaptiveRounds {
+			o.notify(fmt.Sprintf("\n🔄 Round %d (adaptive, up to %d)", round, maxRounds))
+		} else {
+			o.notify(fmt.Sprintf("\n🔄 Round %d of %d", round, maxRounds))
+		}
+		ideasBefore := len(o.Discussion.Ideas)
 
 		if err := o.runExplorationRound(round); err != nil {
 			return fmt.Errorf("round %d failed: %w", round, err)
@@ -190,6 +360,21 @@ func (o *ConfigurableOrchestrator) StartDiscussion(topic string) error {
 		if err := o.runLeaderSynthesis(round); err != nil {
 			return fmt.Errorf("synthesis in round %d failed: %w", round, err)
 		}
+
+		converged := false
+		if o.Config.AdaptiveRounds && round < maxRounds && o.budgetAllows("convergence check", models.BudgetLow) {
+			sig, err := o.checkConvergence(round, ideasBefore)
+			if err != nil {
+				return fmt.Errorf("convergence check failed: %w", err)
+			}
+			converged = sig.Converged && round >= minRounds
+		}
+		o.checkpoint(round)
+		if converged {
+			o.Discussion.StopReason = models.StopReasonConverged
+			o.notify(fmt.Sprintf("  🏁 Discussion converged after round %d — moving to final validation", round))
+			break
+		}
 	}
 
 	// Phase 3: Final validation and selection
@@ -197,17 +382,28 @@ func (o *ConfigurableOrchestrator) StartDiscussion(topic string) error {
 		return fmt.Errorf("final validation failed: %w", err)
 	}
 
+	// Structured plan for the final idea, if none was produced yet
+	if err := o.runPlanning(); err != nil {
+		return fmt.Errorf("planning failed: %w", err)
+	}
+
+	// Executive summary of the outcome
+	if err := o.runExecutiveSummary(); err != nil {
+		return fmt.Errorf("executive summary failed: %w", err)
+	}
+
 	// Phase 4: Visualization
 	if err := o.runVisualization(); err != nil {
 		return fmt.Errorf("visualization failed: %w", err)
 	}
 
-	// Phase 5: Concept map — inject into the idea sheet (non-fatal)
+	// Phase 5: Summary, score table, risk register, plan, concept map and run metrics — inject into the idea sheet (non-fatal)
+	o.appendSummary()
+	o.appendScoreTable()
+	o.appendRiskRegister()
+	o.appendPlan()
 	o.appendConceptMap()
-
-	o.Discussion.EndTime = time.Now()
-	o.Discussion.Status = "completed"
-	o.notify("\n✅ Discussion completed successfully!")
+	o.appendMetrics()
 
 	return nil
 }
@@ -215,6 +411,7 @@ func (o *ConfigurableOrchestrator) StartDiscussion(topic string) error {
 // runKickoff - Team leader introduces the topic
 func (o *ConfigurableOrchestrator) runKickoff() error {
 	o.notify("📋 Phase 1: Team Leader Kickoff")
+	o.startPhase(PhaseKickoff)
 
 	leader, ok := o.Agents[models.RoleTeamLeader]
 	if !ok {
@@ -229,7 +426,7 @@ Team members: %s
 Please set the direction for this discussion. What should each team member focus on?`,
 		o.Config.TeamSize(), o.Discussion.Topic, teamMembers)
 
-	response, err := leader.Process(o.Discussion, input)
+	response, err := o.process(models.RoleTeamLeader, leader, o.Discussion, input)
 	if err != nil {
 		return err
 	}
@@ -243,7 +440,27 @@ Please set the direction for this discussion. What should each team member focus
 // runExplorationRound - Agents contribute in sequence, building on each other
 func (o *ConfigurableOrchestrator) runExplorationRound(round int) error {
 	o.notify(fmt.Sprintf("💡 Exploration Round %d", round))
+	o.startPhase(PhaseExploration)
 
+	o.questions = nil
+	var err error
+	leader := o.Agents[models.RoleTeamLeader]
+	if director, ok := leader.(agents.TurnDirector); ok && o.Config.DynamicTurns {
+		err = o.runDirectedRound(round, leader, director)
+	} else {
+		err = o.runFixedTurns(round)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Addressed questions are answered before the leader synthesizes the round
+	return o.answerQuestions()
+}
+
+// runFixedTurns runs the exploration agents in the fixed order: researcher,
+// ideation passes, critic, implementer, then custom and registered roles.
+func (o *ConfigurableOrchestrator) runFixedTurns(round int) error {
 	// Research phase (if researcher is available)
 	if _, hasResearcher := o.Agents[models.RoleResearcher]; hasResearcher {
 		if err := o.runAgentContribution(models.RoleResearcher, "Provide research and context for this topic"); err != nil {
@@ -258,6 +475,9 @@ func (o *ConfigurableOrchestrator) runExplorationRound(round int) error {
 			count = 1
 		}
 		for pass := 0; pass < count; pass++ {
+			if pass > 0 && !o.budgetAllows("extra ideation passes", models.BudgetLow) {
+				break
+			}
 			prompt := "Generate creative ideas based on the discussion so far"
 			if round > 1 || pass > 0 {
 				prompt = "Building on previous ideas and feedback, generate refined or new creative ideas"
@@ -282,6 +502,13 @@ func (o *ConfigurableOrchestrator) runExplorationRound(round int) error {
 		}
 	}
 
+	// Custom and registered roles that join exploration, in team order
+	for _, turn := range o.phaseTurns(models.CustomPhaseExploration) {
+		if err := o.runAgentContribution(turn.role, turn.prompt); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -291,35 +518,79 @@ func (o *ConfigurableOrchestrator) runAgentContribution(role models.AgentRole, p
 	if !ok {
 		return nil // Agent not in team
 	}
+	if !o.agentAllowed(role, agent.GetName()) {
+		return nil
+	}
 
 	o.notify(fmt.Sprintf("  🗣️  %s contributing...", agent.GetName()))
 
-	// Wire streaming and notify callbacks on the agent's BaseAgent before Process()
-	if ba, ok := getBaseAgent(agent); ok {
-		if o.OnChunk != nil {
-			roleStr := string(role)
-			ba.OnChunk = func(chunk string) { o.OnChunk(roleStr, chunk) }
+	response, err := o.process(role, agent, o.Discussion, prompt+o.questionHint(role))
+	if err != nil {
+		return o.recordFailure(role, agent.GetName(), err)
+	}
+
+	o.absorbResponse(role, response)
+	id := o.addMessage(string(role), "team", response.Content, string(role))
+	o.raiseQuestions(role, response.Content, id)
+
+	return nil
+}
+
+// absorbResponse records what an agent's response contributed beyond its
+// text: evidence, risks, plans and ideas (merging near-duplicates).
+func (o *ConfigurableOrchestrator) absorbResponse(role models.AgentRole, response *models.AgentResponse) {
+	o.fireEvidence(role, response)
+	if n := o.addRisks(role, response.Risks); n > 0 {
+		o.notify(fmt.Sprintf("  📋 %d risk(s) added to the risk register", n))
+	}
+	o.addPlans(role, response.Plans)
+
+	// Add ideas if any were generated, merging near-duplicates
+	if len(response.Ideas) > 0 {
+		var ideaTitles []string
+		for _, idea := range o.addIdeas(role, response.Ideas) {
+			ideaTitles = append(ideaTitles, idea.Title)
 		}
-		ba.Notify = func(msg string) { o.notify(msg) }
-		// Propagate Firecrawl key so the researcher uses the per-request key.
-		if o.FirecrawlKey != "" {
-			ba.FirecrawlKey = o.FirecrawlKey
+		if len(ideaTitles) == 0 {
+			ideaTitles = append(ideaTitles, "nothing new — all duplicates of existing ideas")
 		}
-		defer func() {
-			ba.OnChunk = nil
-			ba.Notify = nil
-		}()
+		speechText := fmt.Sprintf("💡 Proposed: %s", strings.Join(ideaTitles, " | "))
+		o.notify(fmt.Sprintf("  📣 [%s] %s", string(role), o.truncate(speechText, 200)))
+	} else {
+		o.notify(fmt.Sprintf("  📣 [%s] %s", string(role), o.truncate(response.Content, 200)))
 	}
+}
 
-	response, err := agent.Process(o.Discussion, prompt)
-	if err != nil {
-		log.Printf("Warning: %s contribution failed: %v", agent.GetName(), err)
-		return nil // Don't fail the whole discussion
+// wireAgent sets the streaming, notify and Firecrawl hooks on the agent
+// before Process(). The returned func clears the callbacks again.
+func (o *ConfigurableOrchestrator) wireAgent(role models.AgentRole, agent agents.Agent) func() {
+	h, ok := agent.(agents.Hooks)
+	if !ok {
+		return func() {}
 	}
+	roleStr := string(role)
+	h.SetOnChunk(func(chunk string) {
+		o.emit(Event{Type: EventAgentChunk, Role: roleStr, Text: chunk})
+	})
+	h.SetNotify(func(msg string) { o.notify(msg) })
+	// Propagate Firecrawl key so the researcher uses the per-request key.
+	if o.FirecrawlKey != "" {
+		h.SetFirecrawlKey(o.FirecrawlKey)
+	}
+	return func() {
+		h.SetOnChunk(nil)
+		h.SetNotify(nil)
+	}
+}
 
-	// Fire evidence callback. For the researcher, always fire even if no tool
-	// was called (some models answer directly without invoking web_search).
-	if o.OnEvidence != nil && role == models.RoleResearcher {
+// fireEvidence forwards search results from an agent response to OnEvidence.
+// For the researcher, it always fires even if no tool was called (some models
+// answer directly without invoking web_search).
+func (o *ConfigurableOrchestrator) fireEvidence(role models.AgentRole, response *models.AgentResponse) {
+	if o.OnEvidence == nil {
+		return
+	}
+	if role == models.RoleResearcher {
 		results := response.SearchResults
 		if len(results) == 0 {
 			// Synthetic placeholder so the evidence badge always appears
@@ -329,50 +600,39 @@ func (o *ConfigurableOrchestrator) runAgentContribution(role models.AgentRole, p
 			}}
 		}
 		o.OnEvidence(string(role), results)
-	} else if len(response.SearchResults) > 0 && o.OnEvidence != nil {
+	} else if len(response.SearchResults) > 0 {
 		o.OnEvidence(string(role), response.SearchResults)
 	}
-
-	// Add ideas if any were generated
-	if len(response.Ideas) > 0 {
-		var ideaTitles []string
-		for _, idea := range response.Ideas {
-			o.Discussion.Ideas = append(o.Discussion.Ideas, idea)
-			o.notify(fmt.Sprintf("    💡 New idea: %s", idea.Title))
-			ideaTitles = append(ideaTitles, idea.Title)
-		}
-		speechText := fmt.Sprintf("💡 Proposed: %s", strings.Join(ideaTitles, " | "))
-		o.notify(fmt.Sprintf("  📣 [%s] %s", string(role), o.truncate(speechText, 200)))
-	} else {
-		o.notify(fmt.Sprintf("  📣 [%s] %s", string(role), o.truncate(response.Content, 200)))
-	}
-
-	o.addMessage(string(role), "team", response.Content, string(role))
-
-	return nil
 }
 
 // runLeaderSynthesis - Leader synthesizes the round and directs next steps
 func (o *ConfigurableOrchestrator) runLeaderSynthesis(round int) error {
 	leader, ok := o.Agents[models.RoleTeamLeader]
-	if !ok {
+	if !ok || o.agentOverBudget(models.RoleTeamLeader) || !o.budgetAllows("leader synthesis", models.BudgetReserve) {
+		o.recordRoundSummary(round, "", "")
 		return nil
 	}
 
 	o.notify(fmt.Sprintf("  🎯 Team Leader synthesizing round %d...", round))
+	o.startPhase(PhaseSynthesis)
 
 	prompt := fmt.Sprintf(`Synthesize the contributions from round %d.
 
 What are the key insights? What should the team focus on in the next round?
 If this is the final round, identify which ideas are strongest.`, round)
+	prompt += roundSummaryPrompt
+	if o.Config.AdaptiveRounds {
+		prompt += convergencePrompt
+	}
 
-	response, err := leader.Process(o.Discussion, prompt)
+	response, err := o.process(models.RoleTeamLeader, leader, o.Discussion, prompt)
 	if err != nil {
 		return err
 	}
 
-	o.addMessage("system", string(models.RoleTeamLeader), response.Content, "synthesis")
+	id := o.addMessage("system", string(models.RoleTeamLeader), response.Content, "synthesis")
 	o.notify(fmt.Sprintf("  📣 [team_leader] %s", o.truncate(response.Content, 200)))
+	o.recordRoundSummary(round, id, response.Content)
 
 	return nil
 }
@@ -380,47 +640,92 @@ If this is the final round, identify which ideas are strongest.`, round)
 // runFinalValidation - Moderator does final evaluation
 func (o *ConfigurableOrchestrator) runFinalValidation() error {
 	o.notify("\n🔍 Phase: Final Validation")
+	o.startPhase(PhaseValidation)
 
 	moderator, ok := o.Agents[models.RoleModerator]
 	if !ok {
 		// If no moderator, skip validation
+		if o.Config.DeepDive && o.budgetAllows("deep dives", models.BudgetLow) {
+			if err := o.runDeepDives(); err != nil {
+				return err
+			}
+		}
 		return o.runLeaderSelection()
 	}
+	if o.agentOverBudget(models.RoleModerator) || !o.budgetAllows("final validation", models.BudgetExhausted) {
+		return o.autoSelectBestIdea()
+	}
 
 	if len(o.Discussion.Ideas) == 0 {
 		return fmt.Errorf("no ideas to validate")
 	}
 
-	response, err := moderator.Process(o.Discussion,
-		"Provide final scores and comprehensive evaluation of all ideas discussed")
-	if err != nil {
-		return err
+	// Interim scoring in adaptive rounds evaluates the live ideas; clear it so
+	// an idea the final evaluation skips is reported unscored rather than
+	// keeping, and possibly winning on, its interim score
+	for i := range o.Discussion.Ideas {
+		o.Discussion.Ideas[i].ClearEvaluation()
 	}
 
-	o.addMessage("system", string(models.RoleModerator), response.Content, "validation")
-	o.notify(fmt.Sprintf("  📣 [moderator] Evaluating and scoring all ideas..."))
+	if len(o.Config.Judges) > 0 {
+		if err := o.runJudgingPanel(moderator); err != nil {
+			return err
+		}
+	} else {
+		response, err := o.process(models.RoleModerator, moderator, o.Discussion,
+			"Provide final scores and comprehensive evaluation of all ideas discussed")
+		if err != nil {
+			return err
+		}
 
-	// Show scores
-	for _, idea := range o.Discussion.Ideas {
-		if idea.Validated {
+		o.addMessage("system", string(models.RoleModerator), response.Content, "validation")
+		o.notify(fmt.Sprintf("  📣 [moderator] Evaluating and scoring all ideas..."))
+	}
+
+	// Show scores, and report ideas the evaluation did not cover instead of
+	// letting them rank silently at 0
+	o.Discussion.UnscoredIdeas = nil
+	for i, idea := range o.Discussion.Ideas {
+		if !idea.Validated {
+			o.Discussion.UnscoredIdeas = append(o.Discussion.UnscoredIdeas, idea.ID)
+			o.notify(fmt.Sprintf("  ⚠️  [%s] %s - not scored by the evaluation", o.Discussion.IdeaHandle(i), idea.Title))
+			continue
+		}
+		o.emitIdea(EventIdeaScored, models.RoleModerator, idea)
+		if idea.Disputed {
+			o.notify(fmt.Sprintf("  📊 %s - Score: %.1f/10 ⚠️ judges disagree (variance %.1f)", idea.Title, idea.Score, idea.ScoreVariance))
+		} else {
 			o.notify(fmt.Sprintf("  📊 %s - Score: %.1f/10", idea.Title, idea.Score))
 		}
 	}
 
+	if o.Config.DeepDive && o.budgetAllows("deep dives", models.BudgetLow) {
+		if err := o.runDeepDives(); err != nil {
+			return err
+		}
+	}
+
+	if o.Config.Tournament && o.budgetAllows("tournament", models.BudgetLow) {
+		if err := o.runTournament(); err != nil {
+			return err
+		}
+	}
+
 	return o.runLeaderSelection()
 }
 
 // runLeaderSelection - Leader selects the best idea
 func (o *ConfigurableOrchestrator) runLeaderSelection() error {
 	leader, ok := o.Agents[models.RoleTeamLeader]
-	if !ok {
+	if !ok || o.agentOverBudget(models.RoleTeamLeader) || !o.budgetAllows("leader selection", models.BudgetReserve) {
 		// Auto-select highest scored idea
 		return o.autoSelectBestIdea()
 	}
 
 	o.notify("\n🎯 Phase: Final Selection")
+	o.startPhase(PhaseSelection)
 
-	response, err := leader.Process(o.Discussion,
+	response, err := o.process(models.RoleTeamLeader, leader, o.Discussion,
 		"Based on all the discussion, evaluation, and team input, select the best idea and explain your decision")
 	if err != nil {
 		return err
@@ -433,21 +738,27 @@ func (o *ConfigurableOrchestrator) runLeaderSelection() error {
 	return o.autoSelectBestIdea()
 }
 
-// autoSelectBestIdea selects the highest-scored idea
+// autoSelectBestIdea selects the highest-rated idea when a tournament ran,
+// otherwise the highest-scored idea
 func (o *ConfigurableOrchestrator) autoSelectBestIdea() error {
-	var bestIdea *models.Idea
-	bestScore := 0.0
-
-	for i := range o.Discussion.Ideas {
-		if o.Discussion.Ideas[i].Score > bestScore {
-			bestScore = o.Discussion.Ideas[i].Score
-			bestIdea = &o.Discussion.Ideas[i]
+	bestIdea := o.bestRatedIdea()
+	if bestIdea == nil {
+		bestScore := 0.0
+		for i := range o.Discussion.Ideas {
+			if o.Discussion.Ideas[i].Score > bestScore {
+				bestScore = o.Discussion.Ideas[i].Score
+				bestIdea = &o.Discussion.Ideas[i]
+			}
 		}
 	}
 
 	if bestIdea != nil {
 		o.Discussion.FinalIdea = bestIdea
-		o.notify(fmt.Sprintf("  ⭐ Final Idea: %s (Score: %.1f/10)", bestIdea.Title, bestIdea.Score))
+		if bestIdea.Rating > 0 {
+			o.notify(fmt.Sprintf("  ⭐ Final Idea: %s (Score: %.1f/10, Rating: %.0f)", bestIdea.Title, bestIdea.Score, bestIdea.Rating))
+		} else {
+			o.notify(fmt.Sprintf("  ⭐ Final Idea: %s (Score: %.1f/10)", bestIdea.Title, bestIdea.Score))
+		}
 	}
 
 	return nil
@@ -455,15 +766,21 @@ func (o *ConfigurableOrchestrator) autoSelectBestIdea() error {
 
 // runVisualization - Create the idea sheet
 func (o *ConfigurableOrchestrator) runVisualization() error {
-	uiCreator, ok := o.Agents[models.RoleUICreator]
+	uiCreator, ok := o.Agents[models.RoleUICreator].(agents.ReportGenerator)
 	if !ok {
 		return nil // Optional
 	}
+	if o.agentOverBudget(models.RoleUICreator) || !o.budgetAllows("idea sheet", models.BudgetExhausted) {
+		return nil
+	}
 
 	o.notify("\n🎨 Phase: Creating Visual Idea Sheet")
+	o.startPhase(PhaseVisualization)
+	o.emit(Event{Type: EventAgentStarted, Phase: o.phase, Role: string(models.RoleUICreator)})
 
-	html, err := uiCreator.(*agents.UICreatorAgent).GenerateIdeaSheet(o.Discussion)
+	html, err := uiCreator.GenerateIdeaSheet(o.Discussion)
 	if err != nil {
+		o.emitError(models.RoleUICreator, err)
 		o.notify(fmt.Sprintf("  ⚠️ Report generation failed: %s", err.Error()))
 		o.notify("  📣 [ui_creator] Sorry, couldn't generate the report this time!")
 		// Non-fatal — don't fail the whole discussion over a visualization error
@@ -474,6 +791,7 @@ func (o *ConfigurableOrchestrator) runVisualization() error {
 	o.addMessage(string(models.RoleUICreator), "team", html, "visualization")
 
 	o.notify("  ✨ Idea sheet generated successfully")
+	o.emit(Event{Type: EventAgentFinished, Phase: o.phase, Role: string(models.RoleUICreator), Text: "Idea sheet created — painting the final vision!"})
 	o.notify("  📣 [ui_creator] Idea sheet created — painting the final vision!")
 
 	return nil
@@ -481,25 +799,39 @@ func (o *ConfigurableOrchestrator) runVisualization() error {
 
 // Helper methods
 
-// runModelAssignment asks the team leader to assign models to each agent.
+// runModelAssignment assigns a model to each agent before kickoff: by the
+// model policy, or by asking the team leader in ModelAssignLLM mode.
 func (o *ConfigurableOrchestrator) runModelAssignment() error {
 	o.notify("🧠 Phase 0: Model Assignment")
+	o.startPhase(PhaseModelAssignment)
+
+	if o.Config.ModelAssignment == models.ModelAssignLLM {
+		return o.runLLMModelAssignment()
+	}
+	return o.runPolicyModelAssignment()
+}
 
+// runLLMModelAssignment asks the team leader to assign models to each agent.
+// Assignments the model policy rules out are skipped. When no assignment can
+// be made every agent keeps the default model; a failed leader call is
+// recorded, and only returned as an error with StrictFailures.
+func (o *ConfigurableOrchestrator) runLLMModelAssignment() error {
 	leader, ok := o.Agents[models.RoleTeamLeader]
 	if !ok {
-		return fmt.Errorf("team leader is required for model assignment")
+		o.notify("  ⚠️  Model assignment needs a team leader (using default for all agents)")
+		return nil
 	}
 
 	// Discover available models
 	availableModels, err := llm.ListModels(o.BackendConfig)
 	if err != nil {
 		o.notify(fmt.Sprintf("  ⚠️  Could not list models: %s (using default for all agents)", err))
-		return err
+		return nil
 	}
 
 	if len(availableModels) == 0 {
 		o.notify("  ⚠️  No models returned from API (using default for all agents)")
-		return fmt.Errorf("no models available")
+		return nil
 	}
 
 	// Build a model list string
@@ -536,17 +868,17 @@ Respond with ONLY a JSON object mapping agent role to model ID. Example:
 
 JSON response:`, strings.Join(modelList, "\n"), strings.Join(agentRoster, ", "), o.BackendConfig.Model)
 
-	response, err := leader.Process(o.Discussion, prompt)
+	response, err := o.process(models.RoleTeamLeader, leader, o.Discussion, prompt)
 	if err != nil {
-		o.notify(fmt.Sprintf("  ⚠️  Model assignment failed: %s (using default)", err))
-		return err
+		o.notify("  ⚠️  Model assignment failed (using default for all agents)")
+		return o.recordFailure(models.RoleTeamLeader, leader.GetName(), fmt.Errorf("model assignment: %w", err))
 	}
 
 	// Parse the JSON assignments
 	assignments := o.parseModelAssignments(response.Content, modelList)
 	if len(assignments) == 0 {
 		o.notify("  ⚠️  Could not parse model assignments (using default for all agents)")
-		return fmt.Errorf("no valid assignments parsed")
+		return o.recordFailure(models.RoleTeamLeader, leader.GetName(), fmt.Errorf("model assignment: %w", llm.ErrMalformedResponse))
 	}
 
 	// Apply assignments: reinitialize agents with assigned models
@@ -555,10 +887,15 @@ JSON response:`, strings.Join(modelList, "\n"), strings.Join(agentRoster, ", "),
 		if _, exists := o.Agents[agentRole]; !exists {
 			continue
 		}
+		if why := o.modelRejection(agentRole, model); why != "" {
+			o.notify(fmt.Sprintf("  ⚠️  [%s] %s rejected: %s", role, model, why))
+			continue
+		}
 		if err := o.reinitAgent(agentRole, model); err != nil {
 			log.Printf("Warning: failed to reassign %s to model %s: %v", role, model, err)
 			continue
 		}
+		o.emit(Event{Type: EventModelAssigned, Role: role, Model: model})
 		o.notify(fmt.Sprintf("  🔧 [%s] → %s", role, model))
 	}
 
@@ -643,7 +980,13 @@ func (o *ConfigurableOrchestrator) getTeamMembersList() string {
 	return result
 }
 
-func (o *ConfigurableOrchestrator) addMessage(from, to, content, msgType string) {
+// addMessage appends a message to the discussion and returns its ID.
+func (o *ConfigurableOrchestrator) addMessage(from, to, content, msgType string) string {
+	return o.addReply(from, to, content, msgType, "")
+}
+
+// addReply appends a message threaded under replyTo and returns its ID.
+func (o *ConfigurableOrchestrator) addReply(from, to, content, msgType, replyTo string) string {
 	msg := models.Message{
 		ID:        uuid.New().String(),
 		From:      from,
@@ -651,10 +994,14 @@ func (o *ConfigurableOrchestrator) addMessage(from, to, content, msgType string)
 		Content:   content,
 		Timestamp: time.Now(),
 		Type:      msgType,
+		ReplyTo:   replyTo,
 	}
 	o.Discussion.Messages = append(o.Discussion.Messages, msg)
+	return msg.ID
 }
 
+// notify passes a log line to OnProgress. The changes it reports reach
+// snapshots at the next boundary event.
 func (o *ConfigurableOrchestrator) notify(message string) {
 	if o.OnProgress != nil {
 		o.OnProgress(message)
@@ -670,6 +1017,8 @@ func (o *ConfigurableOrchestrator) truncate(s string, maxLen int) string {
 	return s[:maxLen] + "..."
 }
 
+// GetDiscussion returns the live discussion. Read it only once the discussion
+// has returned; use Snapshot while it runs.
 func (o *ConfigurableOrchestrator) GetDiscussion() *models.Discussion {
 	return o.Discussion
 }
@@ -701,18 +1050,69 @@ func (o *ConfigurableOrchestrator) appendConceptMap() {
 
 	mapHTML := report.RenderConceptMapHTML(data)
 
-	// Find the visualization message and inject the concept map before </body>
+	if o.injectReportSection(mapHTML) {
+		o.notify("  ✅ Concept map injected into idea sheet")
+		return
+	}
+
+	// No visualization message yet — store the map on its own
+	o.addMessage(string(models.RoleUICreator), "team", mapHTML, "concept_map")
+	o.notify("  ✅ Concept map saved as standalone section")
+}
+
+// appendScoreTable injects the data-driven comparative score table into the
+// idea sheet, or stores it on its own if there is no sheet.
+func (o *ConfigurableOrchestrator) appendScoreTable() {
+	tableHTML := report.RenderScoreTableHTML(o.Discussion)
+	if tableHTML == "" {
+		return
+	}
+
+	if o.injectReportSection(tableHTML) {
+		o.notify("  ✅ Score table injected into idea sheet")
+		return
+	}
+	o.addMessage(string(models.RoleUICreator), "team", tableHTML, "score_table")
+}
+
+// appendRiskRegister injects the ideas' risk register into the idea sheet.
+func (o *ConfigurableOrchestrator) appendRiskRegister() {
+	registerHTML := report.RenderRiskRegisterHTML(o.Discussion)
+	if registerHTML == "" {
+		return
+	}
+
+	if o.injectReportSection(registerHTML) {
+		o.notify("  ✅ Risk register injected into idea sheet")
+		return
+	}
+	o.addMessage(string(models.RoleUICreator), "team", registerHTML, "risk_register")
+}
+
+// appendPlan injects the final idea's implementation plan into the idea sheet.
+func (o *ConfigurableOrchestrator) appendPlan() {
+	planHTML := report.RenderPlanHTML(o.Discussion)
+	if planHTML == "" {
+		return
+	}
+
+	if o.injectReportSection(planHTML) {
+		o.notify("  ✅ Implementation plan injected into idea sheet")
+		return
+	}
+	o.addMessage(string(models.RoleUICreator), "team", planHTML, "plan_section")
+}
+
+// injectReportSection inserts an HTML section before </body> of the
+// "visualization" message. It returns false if there is no such message.
+func (o *ConfigurableOrchestrator) injectReportSection(section string) bool {
 	for i := range o.Discussion.Messages {
 		if o.Discussion.Messages[i].Type == "visualization" {
 			o.Discussion.Messages[i].Content = report.InjectIntoHTML(
-				o.Discussion.Messages[i].Content, mapHTML,
+				o.Discussion.Messages[i].Content, section,
 			)
-			o.notify("  ✅ Concept map injected into idea sheet")
-			return
+			return true
 		}
 	}
-
-	// No visualization message yet — store the map on its own
-	o.addMessage(string(models.RoleUICreator), "team", mapHTML, "concept_map")
-	o.notify("  ✅ Concept map saved as standalone section")
+	return false
 }
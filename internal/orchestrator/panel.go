@@ -0,0 +1,185 @@
+package orchestrator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/yourusername/ai-agent-team/internal/agents"
+	"github.com/yourusername/ai-agent-team/internal/llmfactory"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// disputedStdDev is the judge score standard deviation at which an idea is
+// flagged as disputed.
+const disputedStdDev = 1.5
+
+// panelJudge is one member of the final validation panel.
+type panelJudge struct {
+	name  string
+	model string
+	agent agents.Agent
+}
+
+// judgingPanel returns the moderator followed by the configured extra judges.
+// Judges whose client cannot be created are recorded as failed and left out.
+func (o *ConfigurableOrchestrator) judgingPanel(moderator agents.Agent) ([]panelJudge, error) {
+	panel := []panelJudge{{name: "Moderator", model: moderator.GetModel(), agent: moderator}}
+	if !o.budgetAllows("extra judges", models.BudgetLow) {
+		return panel, nil
+	}
+	for _, jc := range o.Config.Judges {
+		model := jc.Model
+		if model == "" {
+			model = moderator.GetModel()
+		}
+		client, err := llmfactory.NewClientWithModel(o.BackendConfig, model)
+		if err != nil {
+			if err := o.recordFailure(models.RoleModerator, fmt.Sprintf("Judge (%s)", jc.Name), fmt.Errorf("judge on model %s: %w", model, err)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		judge := agents.NewModeratorJudge(o.meterClient(models.RoleModerator, model, client), jc.Name, jc.Persona)
+		judge.Model = model
+		judge.SetCriteria(o.Config.ScoringCriteria())
+		panel = append(panel, panelJudge{name: jc.Name, model: model, agent: judge})
+	}
+	return panel, nil
+}
+
+// runJudgingPanel has every judge score the ideas independently, each on its
+// own copy of the discussion, then aggregates the scores onto the real ideas.
+// Earlier evaluations are cleared in each copy, so an idea a judge skips is
+// not counted with its interim score, and an idea no judge scores is left
+// unscored. Pros and cons come from the first judge that evaluated an idea.
+// Per-criterion scores are aggregated across judges and weighted into the
+// overall score; the judges' overall scores give the variance.
+func (o *ConfigurableOrchestrator) runJudgingPanel(moderator agents.Agent) error {
+	panel, err := o.judgingPanel(moderator)
+	if err != nil {
+		return err
+	}
+	o.notify(fmt.Sprintf("  ⚖️  Judging panel of %d (%s aggregation)", len(panel), o.aggregation()))
+
+	prompt := "Provide final scores and comprehensive evaluation of all ideas discussed"
+	var evaluated []*models.Discussion
+	for _, judge := range panel {
+		view := *o.Discussion
+		view.Ideas = models.CloneIdeas(o.Discussion.Ideas)
+		for k := range view.Ideas {
+			view.Ideas[k].ClearEvaluation()
+		}
+
+		response, err := o.process(models.RoleModerator, judge.agent, &view, prompt)
+		if err != nil {
+			if err := o.recordFailure(models.RoleModerator, judge.agent.GetName(), fmt.Errorf("judging: %w", err)); err != nil {
+				return err
+			}
+			evaluated = append(evaluated, nil)
+			continue
+		}
+		evaluated = append(evaluated, &view)
+		o.addMessage("system", string(models.RoleModerator), fmt.Sprintf("[Judge: %s, %s]\n%s", judge.name, judge.model, response.Content), "validation")
+		o.notify(fmt.Sprintf("  📣 [moderator] Judge %s scored the ideas", judge.name))
+	}
+
+	scored := false
+	for i := range o.Discussion.Ideas {
+		idea := &o.Discussion.Ideas[i]
+		var scores []float64
+		criterionScores := make(map[string][]float64)
+		idea.JudgeScores = nil
+		for j, view := range evaluated {
+			if view == nil || !view.Ideas[i].Validated {
+				continue
+			}
+			judged := view.Ideas[i]
+			if len(scores) == 0 {
+				idea.Pros, idea.Cons = judged.Pros, judged.Cons
+			}
+			scores = append(scores, judged.Score)
+			for name, s := range judged.CriterionScores {
+				criterionScores[name] = append(criterionScores[name], s)
+			}
+			idea.JudgeScores = append(idea.JudgeScores, models.JudgeScore{Judge: panel[j].name, Model: panel[j].model, Score: judged.Score})
+		}
+		if len(scores) == 0 {
+			idea.ClearEvaluation()
+			continue
+		}
+
+		idea.CriterionScores = nil
+		if len(criterionScores) > 0 {
+			idea.CriterionScores = make(map[string]float64, len(criterionScores))
+			for name, cs := range criterionScores {
+				idea.CriterionScores[name] = aggregateScores(cs, o.aggregation())
+			}
+		}
+		// The overall score follows from the aggregated criteria and their
+		// weights, as for a single moderator; the judges' overall scores only
+		// measure their disagreement
+		idea.Score = aggregateScores(scores, o.aggregation())
+		if weighted, ok := models.WeightedScore(idea.CriterionScores, o.Config.ScoringCriteria()); ok {
+			idea.Score = weighted
+		}
+		idea.ScoreVariance = variance(scores)
+		idea.Disputed = len(scores) > 1 && math.Sqrt(idea.ScoreVariance) >= disputedStdDev
+		idea.Validated = true
+		scored = true
+	}
+
+	if !scored {
+		return fmt.Errorf("no judge produced scores")
+	}
+	return nil
+}
+
+// aggregation returns the configured score aggregation method.
+func (o *ConfigurableOrchestrator) aggregation() string {
+	if models.IsAggregation(o.Config.ScoreAggregation) {
+		return o.Config.ScoreAggregation
+	}
+	return models.AggregateMean
+}
+
+// aggregateScores combines judge scores by mean, median or trimmed mean. The
+// trimmed mean drops the top and bottom 20% (at least one each with 3+ scores).
+func aggregateScores(scores []float64, method string) float64 {
+	sorted := append([]float64(nil), scores...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+
+	switch method {
+	case models.AggregateMedian:
+		if n%2 == 1 {
+			return sorted[n/2]
+		}
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	case models.AggregateTrimmedMean:
+		k := int(float64(n) * 0.2)
+		if k == 0 && n >= 3 {
+			k = 1
+		}
+		sorted = sorted[k : n-k]
+	}
+	return mean(sorted)
+}
+
+func mean(xs []float64) float64 {
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// variance returns the population variance of xs.
+func variance(xs []float64) float64 {
+	m := mean(xs)
+	var sum float64
+	for _, x := range xs {
+		sum += (x - m) * (x - m)
+	}
+	return sum / float64(len(xs))
+}
@@ -0,0 +1,35 @@
+package orchestrator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// TestAggregateScores checks each aggregation method, including how many
+// scores the trimmed mean drops at each panel size.
+func TestAggregateScores(t *testing.T) {
+	tests := []struct {
+		method string
+		scores []float64
+		want   float64
+	}{
+		{models.AggregateMean, []float64{7, 8, 9}, 8},
+		{models.AggregateMean, []float64{2, 9, 10}, 7},
+		{models.AggregateMedian, []float64{9, 1, 8}, 8},
+		{models.AggregateMedian, []float64{10, 1, 3, 2}, 2.5},
+		{models.AggregateMedian, []float64{6}, 6},
+		{models.AggregateTrimmedMean, []float64{6, 8}, 7},                           // too few to trim
+		{models.AggregateTrimmedMean, []float64{1, 8, 9}, 8},                        // one off each end
+		{models.AggregateTrimmedMean, []float64{9, 1, 8, 8}, 8},                     // 20% of 4 rounds down, still one
+		{models.AggregateTrimmedMean, []float64{0, 7, 8, 9, 10}, 8},                 // 20% of 5 is one
+		{models.AggregateTrimmedMean, []float64{0, 0, 5, 6, 7, 7, 8, 9, 10, 10}, 7}, // 20% of 10 is two
+		{"", []float64{2, 9, 10}, 7},                                                // unknown methods average
+	}
+	for _, tt := range tests {
+		if got := aggregateScores(tt.scores, tt.method); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("aggregateScores(%v, %q) = %v, want %v", tt.scores, tt.method, got, tt.want)
+		}
+	}
+}
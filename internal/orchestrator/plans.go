@@ -0,0 +1,94 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// addPlans validates the plans an agent proposed and stores each valid one on
+// its idea, replacing any earlier plan. It returns how many were stored and the
+// validation errors of the plans it rejected.
+func (o *ConfigurableOrchestrator) addPlans(role models.AgentRole, plans []models.IdeaPlan) (int, error) {
+	added := 0
+	var errs []error
+	for _, p := range plans {
+		idea := o.findIdea(p.IdeaID)
+		if idea == nil {
+			continue
+		}
+		if err := p.Plan.Validate(); err != nil {
+			o.notify(fmt.Sprintf("    ⚠️  Rejected %s's plan for %s: %s", role, idea.Title, o.truncate(err.Error(), 200)))
+			errs = append(errs, fmt.Errorf("plan for %q: %w", idea.Title, err))
+			continue
+		}
+
+		plan := p.Plan.Clone()
+		plan.CreatedBy = string(role)
+		plan.Round = o.Discussion.Round
+		plan.Schedule()
+		idea.Plan = plan
+		added++
+		o.notify(fmt.Sprintf("    🗓️  Plan for %s: %d phases, %d tasks, %.0f weeks", idea.Title, len(plan.Phases), len(plan.Tasks()), plan.TotalWeeks()))
+	}
+	return added, errors.Join(errs...)
+}
+
+// runPlanning asks the implementer for a structured plan for the final idea
+// when no valid plan was produced during the discussion. A plan that fails
+// validation is sent back once with the errors. A failed call is recorded,
+// and only returned as an error with StrictFailures.
+func (o *ConfigurableOrchestrator) runPlanning() error {
+	final := o.Discussion.FinalIdea
+	if final == nil || final.Plan != nil {
+		return nil
+	}
+	implementer, ok := o.Agents[models.RoleImplementer]
+	if !ok || !o.agentAllowed(models.RoleImplementer, implementer.GetName()) ||
+		!o.budgetAllows("implementation plan", models.BudgetReserve) {
+		return nil
+	}
+
+	o.notify("\n🗓️  Phase: Implementation Plan")
+	o.startPhase(PhasePlanning)
+
+	// Like a deep dive, the implementer only sees the final idea
+	focus := &models.Discussion{
+		ID:        o.Discussion.ID,
+		Topic:     o.Discussion.Topic,
+		StartTime: o.Discussion.StartTime,
+		Messages:  []models.Message{},
+		Ideas:     []models.Idea{*final},
+		Status:    o.Discussion.Status,
+		Round:     o.Discussion.Round,
+		MaxRounds: o.Discussion.MaxRounds,
+	}
+	prompt := fmt.Sprintf("Produce a structured implementation plan for the selected idea %q: phases with milestones, tasks with effort estimates and dependencies, the roles needed, and success metrics.", final.Title)
+
+	for attempt := 0; attempt < 2; attempt++ {
+		response, err := o.process(models.RoleImplementer, implementer, focus, prompt)
+		if err != nil {
+			return o.recordFailure(models.RoleImplementer, implementer.GetName(), fmt.Errorf("planning %q: %w", final.Title, err))
+		}
+		o.addMessage(string(models.RoleImplementer), "team", response.Content, "plan")
+
+		added, err := o.addPlans(models.RoleImplementer, response.Plans)
+		if added > 0 {
+			return nil
+		}
+		if err == nil {
+			o.notify("  ⚠️ The implementer did not return a structured plan")
+			return nil
+		}
+		o.metrics.retrying(models.RoleImplementer)
+		prompt = fmt.Sprintf("Your plan for %q failed validation:\n%s\nReturn a corrected plan in the same JSON format.", final.Title, err)
+		focus.Messages = append(focus.Messages, models.Message{
+			From:    string(models.RoleImplementer),
+			To:      "team",
+			Content: response.Content,
+			Type:    "plan",
+		})
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// maxQuestionsPerRound caps how many directed questions are answered each
+// round; the rest stay open for the report.
+const maxQuestionsPerRound = 4
+
+// questionHint tells an exploration agent how to address a teammate, naming
+// the teammates who can answer.
+func (o *ConfigurableOrchestrator) questionHint(role models.AgentRole) string {
+	var teammates []string
+	for _, r := range o.Config.GetActiveAgentRoles() {
+		if r != role && r != models.RoleUICreator {
+			teammates = append(teammates, "@"+string(r))
+		}
+	}
+	if len(teammates) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n\nIf you need a specific teammate to answer something before the round ends, add a line \"QUESTION @role: your question\" (teammates: %s).",
+		strings.Join(teammates, ", "))
+}
+
+// raiseQuestions records the directed questions in an agent's contribution as
+// messages threaded under it, and queues those whose addressee is on the team.
+func (o *ConfigurableOrchestrator) raiseQuestions(role models.AgentRole, content, contributionID string) {
+	for _, m := range models.DirectedQuestionPattern.FindAllStringSubmatch(content, -1) {
+		target := models.AgentRole(strings.ToLower(m[1]))
+		if target == role {
+			continue
+		}
+		o.addReply(string(role), string(target), m[2], models.MessageQuestion, contributionID)
+		question := o.Discussion.Messages[len(o.Discussion.Messages)-1]
+		if _, ok := o.Agents[target]; !ok || target == models.RoleUICreator {
+			o.notify(fmt.Sprintf("  ❓ [%s → %s] %s (no one to answer — left open)", role, target, o.truncate(m[2], 150)))
+			continue
+		}
+		o.notify(fmt.Sprintf("  ❓ [%s → %s] %s", role, target, o.truncate(m[2], 150)))
+		o.questions = append(o.questions, question)
+	}
+}
+
+// answerQuestions routes each queued question to its addressee and records the
+// reply threaded under the question. Questions past the per-round cap, or that
+// the budget or a failure leaves unanswered, stay open. A failed answer only
+// stops the discussion with StrictFailures.
+func (o *ConfigurableOrchestrator) answerQuestions() error {
+	queued := o.questions
+	o.questions = nil
+	if len(queued) == 0 {
+		return nil
+	}
+	if len(queued) > maxQuestionsPerRound {
+		o.notify(fmt.Sprintf("  ❓ %d question(s) over this round's limit left open", len(queued)-maxQuestionsPerRound))
+		queued = queued[:maxQuestionsPerRound]
+	}
+	if !o.budgetAllows("answering questions", models.BudgetLow) {
+		return nil
+	}
+
+	for _, q := range queued {
+		target := models.AgentRole(q.To)
+		agent := o.Agents[target]
+		if !o.agentAllowed(target, agent.GetName()) {
+			continue
+		}
+		asker := q.From
+		if a, ok := o.Agents[models.AgentRole(q.From)]; ok {
+			asker = a.GetName()
+		}
+
+		o.notify(fmt.Sprintf("  💬 %s answering %s...", agent.GetName(), asker))
+		prompt := fmt.Sprintf("%s asks you directly: %q\nAnswer the question directly and concisely, drawing on the discussion so far.", asker, q.Content)
+		response, err := o.process(target, agent, o.Discussion, prompt)
+		if err != nil {
+			if err := o.recordFailure(target, agent.GetName(), fmt.Errorf("answering %s: %w", asker, err)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		o.absorbResponse(target, response)
+		o.addReply(string(target), q.From, response.Content, models.MessageResponse, q.ID)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// addRisks records the risks an agent raised in the register of the idea
+// each targets, skipping ones already recorded, and returns how many were new.
+func (o *ConfigurableOrchestrator) addRisks(role models.AgentRole, risks []models.IdeaRisk) int {
+	added := 0
+	for _, r := range risks {
+		idea := o.findIdea(r.IdeaID)
+		if idea == nil {
+			continue
+		}
+
+		duplicate := false
+		for _, existing := range idea.Risks {
+			if models.SameRisk(existing, r.Risk) {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+
+		risk := r.Risk
+		risk.RaisedBy = string(role)
+		risk.Round = o.Discussion.Round
+		risk.Phase = string(o.phase)
+		idea.Risks = append(idea.Risks, risk)
+		added++
+
+		if risk.Severity == models.RiskHigh {
+			o.notify(fmt.Sprintf("    ⚠️  High-severity %s risk for %s: %s", risk.Category, idea.Title, o.truncate(risk.Description, 120)))
+		}
+	}
+	return added
+}
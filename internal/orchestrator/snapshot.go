@@ -0,0 +1,46 @@
+package orchestrator
+
+import "github.com/yourusername/ai-agent-team/internal/models"
+
+// Snapshot returns a copy of the discussion as of its latest change, or nil
+// before the first discussion starts. It is safe to call from any goroutine
+// while the discussion runs. Snapshots are shared between readers and never
+// change once published, so callers must not modify them; Clone one first.
+func (o *ConfigurableOrchestrator) Snapshot() *models.Discussion {
+	o.snapshotMu.RLock()
+	defer o.snapshotMu.RUnlock()
+	return o.snapshot
+}
+
+// Watch registers a handler that receives a new snapshot each time the
+// discussion changes, and returns a function that removes it. Like event
+// handlers, it runs on the orchestrator goroutine and must not block for long.
+func (o *ConfigurableOrchestrator) Watch(handler func(d *models.Discussion)) (unsubscribe func()) {
+	return o.watchers.subscribe(handler)
+}
+
+// changed publishes a snapshot of the current discussion to Snapshot and the
+// watchers. Only the goroutine running the discussion may call it. Cloning
+// the discussion is not free, so it runs at phase, contribution and idea
+// boundaries (see snapshotEvent) rather than on every event or log line.
+func (o *ConfigurableOrchestrator) changed() {
+	if o.Discussion == nil {
+		return
+	}
+	snap := o.Discussion.Clone()
+	o.snapshotMu.Lock()
+	o.snapshot = snap
+	o.snapshotMu.Unlock()
+	o.watchers.publish(snap)
+}
+
+// snapshotEvent reports whether events of type t mark a boundary that
+// publishes a snapshot. Streamed chunks, agent starts and model announcements
+// change nothing readers need before the next boundary.
+func snapshotEvent(t EventType) bool {
+	switch t {
+	case EventAgentStarted, EventAgentChunk, EventModelAssigned:
+		return false
+	}
+	return true
+}
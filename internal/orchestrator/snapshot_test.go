@@ -0,0 +1,180 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yourusername/ai-agent-team/internal/llm"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// ideaRef matches the ideas listed in a prompt, e.g. "[I1] Title - ..."
+var ideaRef = regexp.MustCompile(`(?m)^\[I\d+\] (.+?) - `)
+
+// fakeTeamServer is an OpenAI-compatible endpoint whose every answer
+// proposes a new idea and scores the ideas listed in the prompt, so a
+// discussion run against it keeps adding messages and ideas.
+func fakeTeamServer() *httptest.Server {
+	var calls atomic.Int64
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		var req struct {
+			Stream   bool `json:"stream"`
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var prompt strings.Builder
+		for _, m := range req.Messages {
+			prompt.WriteString(m.Content)
+		}
+
+		var evals []string
+		for i, m := range ideaRef.FindAllStringSubmatch(prompt.String(), -1) {
+			evals = append(evals, fmt.Sprintf(`{"idea_id":%q,"score":%d,"criteria_scores":{},"pros":["p"],"cons":["c"]}`, m[1], 5+i%4))
+		}
+		content := fmt.Sprintf(`{"ideas":[{"title":"Idea number %d","description":"approach %d for the topic"}],"evaluations":[%s]}`, n, n, strings.Join(evals, ","))
+		if strings.Contains(prompt.String(), "HTML") {
+			content = "<html><body>idea sheet</body></html>"
+		}
+
+		writeChat(w, req.Stream, content)
+	}))
+}
+
+// writeChat answers an OpenAI-compatible chat request with content, as one
+// event-stream chunk when the request streams.
+func writeChat(w http.ResponseWriter, stream bool, content string) {
+	if stream {
+		w.Header().Set("Content-Type", "text/event-stream")
+		chunk, _ := json.Marshal(map[string]any{"choices": []any{map[string]any{"delta": map[string]any{"content": content}}}})
+		fmt.Fprintf(w, "data: %s\n\ndata: [DONE]\n\n", chunk)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"choices": []any{map[string]any{"message": map[string]any{"content": content}, "finish_reason": "stop"}},
+	})
+}
+
+// TestSnapshotConcurrentPolling polls snapshots from several goroutines
+// while a discussion runs, the way the server's status endpoint does. Run
+// it with -race.
+func TestSnapshotConcurrentPolling(t *testing.T) {
+	srv := fakeTeamServer()
+	defer srv.Close()
+
+	config := models.DefaultTeamConfig()
+	config.MaxRounds = 2
+	o := NewConfigurableOrchestrator(&llm.BackendConfig{Backend: "openai", APIKey: "test", BaseURL: srv.URL, Model: "gpt-4o"}, config)
+	o.OnProgress = func(string) {}
+	o.MemoryPath = t.TempDir() + "/memory.json"
+
+	if o.Snapshot() != nil {
+		t.Fatal("Snapshot before the first discussion is not nil")
+	}
+
+	var changes atomic.Int64
+	o.Watch(func(d *models.Discussion) {
+		changes.Add(1)
+		_ = len(d.Ideas)
+	})
+
+	done := make(chan struct{})
+	var polls atomic.Int64
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if d := o.Snapshot(); d != nil {
+					for _, idea := range d.Ideas {
+						_ = idea.Title + fmt.Sprint(idea.Score, idea.CriterionScores)
+					}
+					if _, err := json.Marshal(d); err != nil {
+						t.Errorf("marshal snapshot: %v", err)
+						return
+					}
+					polls.Add(1)
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}()
+	}
+
+	err := o.StartDiscussion("ways to reduce food waste")
+	close(done)
+	wg.Wait()
+	if err != nil {
+		t.Fatalf("StartDiscussion: %v", err)
+	}
+
+	final := o.Snapshot()
+	switch {
+	case final == nil:
+		t.Fatal("no snapshot after the discussion")
+	case final.Status != "completed":
+		t.Errorf("final snapshot status = %q, want completed", final.Status)
+	case len(final.Ideas) != len(o.Discussion.Ideas) || len(final.Messages) != len(o.Discussion.Messages):
+		t.Errorf("final snapshot has %d ideas and %d messages, discussion has %d and %d",
+			len(final.Ideas), len(final.Messages), len(o.Discussion.Ideas), len(o.Discussion.Messages))
+	}
+	if len(final.Ideas) == 0 {
+		t.Error("discussion produced no ideas")
+	}
+	if changes.Load() == 0 {
+		t.Error("watcher saw no changes")
+	}
+	if polls.Load() == 0 {
+		t.Error("no snapshot was polled while the discussion ran")
+	}
+}
+
+// TestSnapshotIsolated checks that a published snapshot does not change when
+// the discussion does.
+func TestSnapshotIsolated(t *testing.T) {
+	o := NewConfigurableOrchestrator(&llm.BackendConfig{Backend: "openai", APIKey: "test", BaseURL: "http://127.0.0.1:0", Model: "gpt-4o"}, models.DefaultTeamConfig())
+	o.OnProgress = func(string) {}
+	o.begin(&models.Discussion{Topic: "t", Ideas: []models.Idea{{ID: "a", Title: "A", Score: 5, CriterionScores: map[string]float64{"Impact": 5}}}})
+
+	snap := o.Snapshot()
+	o.Discussion.Ideas[0].Score = 9
+	o.Discussion.Ideas[0].CriterionScores["Impact"] = 9
+	o.Discussion.Ideas = append(o.Discussion.Ideas, models.Idea{ID: "b"})
+	o.Discussion.Budget.Degradations = append(o.Discussion.Budget.Degradations, "skipped")
+
+	if got := snap.Ideas[0]; got.Score != 5 || got.CriterionScores["Impact"] != 5 || len(snap.Ideas) != 1 {
+		t.Errorf("snapshot changed with the discussion: %+v", snap.Ideas)
+	}
+	if len(snap.Budget.Degradations) != 0 {
+		t.Errorf("snapshot budget changed with the discussion: %v", snap.Budget.Degradations)
+	}
+
+	// Log lines do not publish; the next boundary event does
+	o.notify("changed")
+	if o.Snapshot() != snap {
+		t.Error("notify published a snapshot")
+	}
+	o.emit(Event{Type: EventIdeaScored})
+	if got := o.Snapshot(); len(got.Ideas) != 2 || got.Ideas[0].Score != 9 {
+		t.Errorf("snapshot after IdeaScored = %+v, want the updated ideas", got.Ideas)
+	}
+}
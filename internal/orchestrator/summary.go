@@ -0,0 +1,146 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+	"github.com/yourusername/ai-agent-team/internal/report"
+)
+
+// roundSummaryPrompt is appended to the leader's synthesis prompt so the
+// round can be recorded as a models.RoundSummary.
+const roundSummaryPrompt = `
+
+After your synthesis, add a JSON block summarizing the round:
+{"key_insights": ["short insight", "..."], "focus": "what the next round should concentrate on", "drop": ["handles of ideas to set aside, e.g. I3"]}`
+
+// roundSummaryJSON is the shape of the leader's round summary block.
+type roundSummaryJSON struct {
+	KeyInsights []string `json:"key_insights"`
+	Focus       string   `json:"focus"`
+	Drop        []string `json:"drop"`
+}
+
+// recordRoundSummary stores the summary of a finished round: the ideas first
+// proposed in it and, from the leader's synthesis (if any), the key insights,
+// the focus for the next round and the ideas newly set aside.
+func (o *ConfigurableOrchestrator) recordRoundSummary(round int, synthesisID, synthesis string) {
+	summary := models.RoundSummary{Round: round, MessageID: synthesisID}
+	for _, idea := range o.Discussion.Ideas {
+		if idea.Round == round {
+			summary.IdeasAdded = append(summary.IdeasAdded, idea.ID)
+		}
+	}
+
+	if jsonStr := extractJSON(synthesis); jsonStr != "" {
+		var parsed roundSummaryJSON
+		if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil {
+			log.Printf("Warning: could not parse round %d summary: %v", round, err)
+		} else {
+			for _, insight := range parsed.KeyInsights {
+				if insight = strings.TrimSpace(insight); insight != "" {
+					summary.KeyInsights = append(summary.KeyInsights, insight)
+				}
+			}
+			summary.Focus = strings.TrimSpace(parsed.Focus)
+			dropped := o.Discussion.DroppedIdeas()
+			for _, ref := range parsed.Drop {
+				if idea := o.resolveIdeaRef(ref); idea != nil && !dropped[idea.ID] {
+					dropped[idea.ID] = true
+					summary.IdeasDropped = append(summary.IdeasDropped, idea.ID)
+				}
+			}
+		}
+	}
+
+	o.Discussion.RoundSummaries = append(o.Discussion.RoundSummaries, summary)
+	o.emit(Event{Type: EventRoundSummary, Round: round, Summary: &summary})
+	if summary.Focus != "" {
+		o.notify(fmt.Sprintf("  🧭 Focus for the next round: %s", o.truncate(summary.Focus, 200)))
+	}
+	if n := len(summary.IdeasDropped); n > 0 {
+		o.notify(fmt.Sprintf("  🗑️  Team Leader set aside %d idea(s)", n))
+	}
+}
+
+// resolveIdeaRef finds an idea by handle, ID or exact title
+func (o *ConfigurableOrchestrator) resolveIdeaRef(ref string) *models.Idea {
+	ref = strings.Trim(strings.TrimSpace(ref), "[]")
+	if ref == "" {
+		return nil
+	}
+	for i := range o.Discussion.Ideas {
+		idea := &o.Discussion.Ideas[i]
+		if strings.EqualFold(o.Discussion.IdeaHandle(i), ref) || idea.ID == ref || strings.EqualFold(idea.Title, ref) {
+			return idea
+		}
+	}
+	return nil
+}
+
+// runExecutiveSummary has the team leader write the executive summary of the
+// discussion once the final idea is chosen. Without a leader or budget the
+// summary is assembled from the round summaries and the final idea, which
+// also stands in when the leader's call fails. A failed call is recorded, and
+// only returned as an error with StrictFailures.
+func (o *ConfigurableOrchestrator) runExecutiveSummary() error {
+	o.Discussion.Summary = o.fallbackSummary()
+
+	leader, ok := o.Agents[models.RoleTeamLeader]
+	if !ok || o.Discussion.FinalIdea == nil || o.agentOverBudget(models.RoleTeamLeader) ||
+		!o.budgetAllows("executive summary", models.BudgetReserve) {
+		return nil
+	}
+
+	o.notify("\n📝 Phase: Executive Summary")
+	o.startPhase(PhaseSummary)
+
+	response, err := o.process(models.RoleTeamLeader, leader, o.Discussion, fmt.Sprintf(
+		`Write a short executive summary of this discussion for a decision maker (one paragraph, at most 120 words).
+Cover the recommended idea %q and why it won, the main alternatives considered, and the biggest open risk.
+Reply with the summary text only.`, o.Discussion.FinalIdea.Title))
+	if err != nil {
+		return o.recordFailure(models.RoleTeamLeader, leader.GetName(), fmt.Errorf("executive summary: %w", err))
+	}
+	if content := strings.TrimSpace(response.Content); content != "" {
+		o.Discussion.Summary = content
+		o.addMessage(string(models.RoleTeamLeader), "team", content, "summary")
+	}
+	return nil
+}
+
+// fallbackSummary describes the outcome without an LLM call.
+func (o *ConfigurableOrchestrator) fallbackSummary() string {
+	d := o.Discussion
+	summary := fmt.Sprintf("The team explored %q over %d round(s) and proposed %d idea(s).", d.Topic, d.Round, len(d.Ideas))
+	if f := d.FinalIdea; f != nil {
+		summary += fmt.Sprintf(" %q was selected", f.Title)
+		if f.Validated {
+			summary += fmt.Sprintf(" with a score of %.1f/10", f.Score)
+		}
+		summary += "."
+	} else {
+		summary += " No idea was selected."
+	}
+	if s := d.LatestRoundSummary(); s != nil && len(s.KeyInsights) > 0 {
+		summary += " Key insight: " + strings.TrimSuffix(s.KeyInsights[0], ".") + "."
+	}
+	return summary
+}
+
+// appendSummary injects the executive summary and round timeline into the idea sheet.
+func (o *ConfigurableOrchestrator) appendSummary() {
+	summaryHTML := report.RenderSummaryHTML(o.Discussion)
+	if summaryHTML == "" {
+		return
+	}
+
+	if o.injectReportSection(summaryHTML) {
+		o.notify("  ✅ Executive summary injected into idea sheet")
+		return
+	}
+	o.addMessage(string(models.RoleUICreator), "team", summaryHTML, "summary_section")
+}
@@ -0,0 +1,250 @@
+package orchestrator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/ai-agent-team/internal/agents"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+const (
+	// defaultTournamentRounds is used when TeamConfig.TournamentRounds is unset.
+	defaultTournamentRounds = 3
+	// maxTournamentIdeas bounds the number of contenders (and so judge calls).
+	maxTournamentIdeas = 8
+	// baseRating is the Elo-scale rating of an average contender.
+	baseRating = 1500.0
+)
+
+// runTournament ranks the top ideas with Swiss-format pairwise comparisons
+// judged by the moderator, then fits Bradley-Terry strengths to the outcomes
+// and stores them on each idea as an Elo-scale rating. Failed matches are
+// recorded and skipped, and with no judged match selection falls back to
+// moderator scores; only StrictFailures makes a failed match an error.
+func (o *ConfigurableOrchestrator) runTournament() error {
+	moderator := o.Agents[models.RoleModerator]
+	judge, ok := moderator.(agents.PairwiseJudge)
+	if !ok {
+		o.notify("  ⚠️  Tournament skipped: no moderator to judge")
+		return nil
+	}
+
+	contenders := o.tournamentContenders()
+	n := len(contenders)
+	if n < 2 {
+		return nil
+	}
+
+	o.notify(fmt.Sprintf("\n🏆 Phase: Tournament Ranking (%d ideas)", n))
+	o.startPhase(PhaseTournament)
+	o.emit(Event{Type: EventAgentStarted, Phase: o.phase, Role: string(models.RoleModerator)})
+
+	result := &models.TournamentResult{Format: "swiss", WinMatrix: make([][]int, n)}
+	for i, idx := range contenders {
+		result.IdeaIDs = append(result.IdeaIDs, o.Discussion.Ideas[idx].ID)
+		result.WinMatrix[i] = make([]int, n)
+	}
+
+	rounds := o.Config.TournamentRounds
+	if rounds < 1 {
+		rounds = defaultTournamentRounds
+	}
+	if rounds > n-1 {
+		rounds = n - 1
+	}
+
+	wins := make([]int, n)
+	played := make(map[[2]int]bool)
+	for round := 1; round <= rounds; round++ {
+		if round > 1 && !o.budgetAllows(fmt.Sprintf("tournament rounds %d-%d", round, rounds), models.BudgetLow) {
+			break
+		}
+		for k, pair := range swissPairs(wins, played) {
+			a, b := pair[0], pair[1]
+			played[[2]int{a, b}], played[[2]int{b, a}] = true, true
+
+			// Alternate presentation order to offset position bias
+			if k%2 == 1 {
+				a, b = b, a
+			}
+			ideaA := &o.Discussion.Ideas[contenders[a]]
+			ideaB := &o.Discussion.Ideas[contenders[b]]
+
+			verdict, reason, err := judge.CompareIdeas(o.Discussion, ideaA, ideaB)
+			if err != nil {
+				o.emitError(models.RoleModerator, err)
+				if err := o.recordFailure(models.RoleModerator, moderator.GetName(), fmt.Errorf("tournament match %q vs %q: %w", ideaA.Title, ideaB.Title, err)); err != nil {
+					return err
+				}
+				continue
+			}
+
+			winner, loser := a, b
+			if verdict == "B" {
+				winner, loser = b, a
+			}
+			wins[winner]++
+			result.WinMatrix[winner][loser]++
+			result.Matches = append(result.Matches, models.PairwiseMatch{
+				Round:  round,
+				IdeaA:  ideaA.ID,
+				IdeaB:  ideaB.ID,
+				Winner: result.IdeaIDs[winner],
+				Reason: reason,
+				Judge:  string(models.RoleModerator),
+			})
+			o.notify(fmt.Sprintf("  ⚔️  %s beat %s",
+				o.Discussion.Ideas[contenders[winner]].Title, o.Discussion.Ideas[contenders[loser]].Title))
+		}
+	}
+
+	if len(result.Matches) == 0 {
+		o.emitError(models.RoleModerator, fmt.Errorf("no tournament matches could be judged"))
+		return nil
+	}
+
+	ratings := bradleyTerryRatings(result.WinMatrix)
+	var standings []string
+	for i, idx := range contenders {
+		idea := &o.Discussion.Ideas[idx]
+		idea.Rating = ratings[i]
+		idea.Wins, idea.Losses = 0, 0
+		for j := range contenders {
+			idea.Wins += result.WinMatrix[i][j]
+			idea.Losses += result.WinMatrix[j][i]
+		}
+		o.emitIdea(EventIdeaScored, models.RoleModerator, *idea)
+	}
+	o.Discussion.Tournament = result
+
+	ranked := append([]int(nil), contenders...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return o.Discussion.Ideas[ranked[i]].Rating > o.Discussion.Ideas[ranked[j]].Rating
+	})
+	for place, idx := range ranked {
+		idea := o.Discussion.Ideas[idx]
+		standings = append(standings, fmt.Sprintf("%d. %s — rating %.0f (%d-%d)", place+1, idea.Title, idea.Rating, idea.Wins, idea.Losses))
+	}
+	summary := "Tournament standings:\n" + strings.Join(standings, "\n")
+	o.addMessage(string(models.RoleModerator), "team", summary, "tournament")
+	o.notify("  🏆 " + standings[0])
+	o.emit(Event{Type: EventAgentFinished, Phase: o.phase, Role: string(models.RoleModerator), Text: summary})
+	return nil
+}
+
+// tournamentContenders returns the indexes of the highest-scored ideas,
+// at most maxTournamentIdeas of them.
+func (o *ConfigurableOrchestrator) tournamentContenders() []int {
+	idx := make([]int, len(o.Discussion.Ideas))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		return o.Discussion.Ideas[idx[a]].Score > o.Discussion.Ideas[idx[b]].Score
+	})
+	if len(idx) > maxTournamentIdeas {
+		idx = idx[:maxTournamentIdeas]
+	}
+	return idx
+}
+
+// swissPairs pairs contenders with similar win counts who have not met yet.
+// Ties keep seed order (contenders are seeded by score). With an odd count
+// the lowest-ranked unpaired contender gets a bye.
+func swissPairs(wins []int, played map[[2]int]bool) [][2]int {
+	order := make([]int, len(wins))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return wins[order[a]] > wins[order[b]] })
+
+	paired := make([]bool, len(wins))
+	var pairs [][2]int
+	for i, a := range order {
+		if paired[a] {
+			continue
+		}
+		for _, b := range order[i+1:] {
+			if !paired[b] && !played[[2]int{a, b}] {
+				paired[a], paired[b] = true, true
+				pairs = append(pairs, [2]int{a, b})
+				break
+			}
+		}
+	}
+	return pairs
+}
+
+// bradleyTerryRatings fits Bradley-Terry strengths to a win matrix with the
+// MM algorithm and maps them to an Elo scale centred on baseRating. Each
+// contender gets one virtual win and loss against an average opponent so
+// unbeaten or winless ideas still have finite ratings.
+func bradleyTerryRatings(winMatrix [][]int) []float64 {
+	n := len(winMatrix)
+	strength := make([]float64, n)
+	for i := range strength {
+		strength[i] = 1
+	}
+
+	for iter := 0; iter < 200; iter++ {
+		next := make([]float64, n)
+		for i := 0; i < n; i++ {
+			w := 1.0 // virtual win
+			denom := 2 / (strength[i] + 1)
+			for j := 0; j < n; j++ {
+				if i == j {
+					continue
+				}
+				w += float64(winMatrix[i][j])
+				if games := winMatrix[i][j] + winMatrix[j][i]; games > 0 {
+					denom += float64(games) / (strength[i] + strength[j])
+				}
+			}
+			next[i] = w / denom
+		}
+
+		// Normalise to a geometric mean of 1
+		var logSum float64
+		for _, s := range next {
+			logSum += math.Log(s)
+		}
+		mean := math.Exp(logSum / float64(n))
+		delta := 0.0
+		for i := range next {
+			next[i] /= mean
+			delta = math.Max(delta, math.Abs(next[i]-strength[i]))
+		}
+		strength = next
+		if delta < 1e-6 {
+			break
+		}
+	}
+
+	ratings := make([]float64, n)
+	for i, s := range strength {
+		ratings[i] = baseRating + 400*math.Log10(s)
+	}
+	return ratings
+}
+
+// bestRatedIdea returns the tournament winner by rating, breaking ties on
+// score, or nil if no tournament ran.
+func (o *ConfigurableOrchestrator) bestRatedIdea() *models.Idea {
+	if o.Discussion.Tournament == nil {
+		return nil
+	}
+	var best *models.Idea
+	for i := range o.Discussion.Ideas {
+		idea := &o.Discussion.Ideas[i]
+		if idea.Rating == 0 {
+			continue
+		}
+		if best == nil || idea.Rating > best.Rating || (idea.Rating == best.Rating && idea.Score > best.Score) {
+			best = idea
+		}
+	}
+	return best
+}
@@ -0,0 +1,162 @@
+package orchestrator
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestBradleyTerryRatings checks the fitted ratings order contenders by
+// their record, rate identical records equally and stay finite for unbeaten
+// and winless contenders.
+func TestBradleyTerryRatings(t *testing.T) {
+	tests := []struct {
+		name      string
+		winMatrix [][]int
+		order     []int    // contenders from strongest to weakest
+		equal     [][2]int // contenders that must share a rating
+	}{
+		{
+			name:      "no games",
+			winMatrix: [][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}},
+			equal:     [][2]int{{0, 1}, {1, 2}},
+		},
+		{
+			name:      "split series",
+			winMatrix: [][]int{{0, 1}, {1, 0}},
+			equal:     [][2]int{{0, 1}},
+		},
+		{
+			name:      "transitive",
+			winMatrix: [][]int{{0, 2, 1}, {0, 0, 2}, {0, 0, 0}},
+			order:     []int{0, 1, 2},
+		},
+		{
+			name:      "identical records",
+			winMatrix: [][]int{{0, 1, 1}, {1, 0, 1}, {0, 0, 0}},
+			order:     []int{0, 2},
+			equal:     [][2]int{{0, 1}},
+		},
+		{
+			name:      "unbeaten and winless",
+			winMatrix: [][]int{{0, 3, 3, 3}, {0, 0, 1, 1}, {0, 1, 0, 1}, {0, 0, 0, 0}},
+			order:     []int{0, 1, 3},
+			equal:     [][2]int{{1, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ratings := bradleyTerryRatings(tt.winMatrix)
+			if len(ratings) != len(tt.winMatrix) {
+				t.Fatalf("got %d ratings for %d contenders", len(ratings), len(tt.winMatrix))
+			}
+			// Strengths are normalised to a geometric mean of 1, so the
+			// ratings average baseRating
+			var sum float64
+			for i, r := range ratings {
+				if math.IsNaN(r) || math.IsInf(r, 0) {
+					t.Fatalf("rating %d is %v", i, r)
+				}
+				sum += r
+			}
+			if mean := sum / float64(len(ratings)); math.Abs(mean-baseRating) > 1e-6 {
+				t.Errorf("mean rating = %.6f, want %.0f", mean, baseRating)
+			}
+			for k := 1; k < len(tt.order); k++ {
+				if a, b := tt.order[k-1], tt.order[k]; ratings[a] <= ratings[b] {
+					t.Errorf("rating %d = %.1f, want above rating %d = %.1f", a, ratings[a], b, ratings[b])
+				}
+			}
+			for _, pair := range tt.equal {
+				if a, b := pair[0], pair[1]; math.Abs(ratings[a]-ratings[b]) > 1e-3 {
+					t.Errorf("ratings %d and %d = %.4f and %.4f, want equal", a, b, ratings[a], ratings[b])
+				}
+			}
+		})
+	}
+}
+
+// TestBradleyTerryRatingsConverge checks the fit is a fixed point: feeding
+// the same results again gives the same ratings, and doubling every result
+// spreads them further apart without reordering them.
+func TestBradleyTerryRatingsConverge(t *testing.T) {
+	winMatrix := [][]int{{0, 2, 1, 1}, {1, 0, 2, 0}, {0, 1, 0, 2}, {1, 1, 0, 0}}
+	first, again := bradleyTerryRatings(winMatrix), bradleyTerryRatings(winMatrix)
+	for i := range first {
+		if math.Abs(first[i]-again[i]) > 1e-9 {
+			t.Errorf("rating %d changed between fits: %.9f then %.9f", i, first[i], again[i])
+		}
+	}
+
+	doubled := make([][]int, len(winMatrix))
+	for i, row := range winMatrix {
+		doubled[i] = make([]int, len(row))
+		for j, w := range row {
+			doubled[i][j] = 2 * w
+		}
+	}
+	more := bradleyTerryRatings(doubled)
+	for i := range first {
+		for j := range first {
+			if (first[i] > first[j]) != (more[i] > more[j]) {
+				t.Errorf("contenders %d and %d swap order with more games", i, j)
+			}
+		}
+	}
+	if spread(more) <= spread(first) {
+		t.Errorf("rating spread with doubled results = %.1f, want above %.1f", spread(more), spread(first))
+	}
+}
+
+// spread returns the gap between the highest and lowest rating
+func spread(ratings []float64) float64 {
+	lo, hi := ratings[0], ratings[0]
+	for _, r := range ratings {
+		lo, hi = math.Min(lo, r), math.Max(hi, r)
+	}
+	return hi - lo
+}
+
+// TestSwissPairs checks contenders are paired by win count in seed order,
+// never against someone they already played, and that an odd one out gets
+// a bye.
+func TestSwissPairs(t *testing.T) {
+	played := func(pairs ...[2]int) map[[2]int]bool {
+		m := make(map[[2]int]bool)
+		for _, p := range pairs {
+			m[p], m[[2]int{p[1], p[0]}] = true, true
+		}
+		return m
+	}
+	tests := []struct {
+		name   string
+		wins   []int
+		played map[[2]int]bool
+		want   string
+	}{
+		{"first round in seed order", []int{0, 0, 0, 0}, played(), "[[0 1] [2 3]]"},
+		{"winners meet winners", []int{0, 1, 0, 1}, played(), "[[1 3] [0 2]]"},
+		{"no rematch", []int{1, 1, 0, 0}, played([2]int{0, 1}, [2]int{2, 3}), "[[0 2] [1 3]]"},
+		{"odd count gets a bye", []int{0, 0, 0}, played(), "[[0 1]]"},
+		{"leaders already met", []int{1, 0, 1}, played([2]int{0, 2}), "[[0 1]]"},
+		{"everyone has met", []int{1, 0}, played([2]int{0, 1}), "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs := swissPairs(tt.wins, tt.played)
+			if got := fmt.Sprint(pairs); got != tt.want {
+				t.Errorf("swissPairs(%v) = %s, want %s", tt.wins, got, tt.want)
+			}
+			seen := make(map[int]bool)
+			for _, p := range pairs {
+				if tt.played[p] {
+					t.Errorf("rematch %v", p)
+				}
+				if seen[p[0]] || seen[p[1]] {
+					t.Errorf("contender paired twice in %v", pairs)
+				}
+				seen[p[0]], seen[p[1]] = true, true
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/yourusername/ai-agent-team/internal/agents"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// explorationTurn is an agent that can speak in a leader-directed round.
+type explorationTurn struct {
+	models.TurnCandidate
+	needsIdeas bool // only offered once the discussion has ideas
+}
+
+// explorationTurns returns every exploration participant on the team with
+// its default task for the round, in the fixed-order sequence.
+func (o *ConfigurableOrchestrator) explorationTurns(round int) []explorationTurn {
+	ideationPrompt := "Generate creative ideas based on the discussion so far"
+	if round > 1 {
+		ideationPrompt = "Building on previous ideas and feedback, generate refined or new creative ideas"
+	}
+	builtin := []explorationTurn{
+		{TurnCandidate: models.TurnCandidate{Role: models.RoleResearcher, Prompt: "Provide research and context for this topic"}},
+		{TurnCandidate: models.TurnCandidate{Role: models.RoleIdeation, Prompt: ideationPrompt}},
+		{TurnCandidate: models.TurnCandidate{Role: models.RoleCritic, Prompt: "Challenge the assumptions in these ideas. What could go wrong?"}, needsIdeas: true},
+		{TurnCandidate: models.TurnCandidate{Role: models.RoleImplementer, Prompt: "How would we actually implement these ideas? What's the practical approach?"}, needsIdeas: true},
+	}
+	for _, turn := range o.phaseTurns(models.CustomPhaseExploration) {
+		builtin = append(builtin, explorationTurn{TurnCandidate: models.TurnCandidate{Role: turn.role, Prompt: turn.prompt}})
+	}
+
+	var turns []explorationTurn
+	for _, t := range builtin {
+		if agent, ok := o.Agents[t.Role]; ok {
+			t.Name = agent.GetName()
+			turns = append(turns, t)
+		}
+	}
+	return turns
+}
+
+// runDirectedRound runs an exploration round in which the team leader picks
+// each next speaker and their task, until it says the round is done or the
+// per-round turn cap is reached. If the leader cannot direct the first turn
+// the round falls back to the fixed order.
+func (o *ConfigurableOrchestrator) runDirectedRound(round int, leader agents.Agent, director agents.TurnDirector) error {
+	turns := o.explorationTurns(round)
+	maxTurns := o.Config.MaxTurnsPerRound
+	if maxTurns <= 0 {
+		maxTurns = 2 * len(turns)
+	}
+	if len(turns) == 0 || o.agentOverBudget(models.RoleTeamLeader) || !o.budgetAllows("leader-directed turns", models.BudgetLow) {
+		return o.runFixedTurns(round)
+	}
+
+	spoken := make(map[models.AgentRole]bool)
+	taken := 0
+	for taken < maxTurns {
+		if taken > 0 && !o.budgetAllows("remaining directed turns", models.BudgetLow) {
+			break
+		}
+		var candidates []models.TurnCandidate
+		for _, t := range turns {
+			if !t.needsIdeas || len(o.Discussion.Ideas) > 0 {
+				candidates = append(candidates, t.TurnCandidate)
+			}
+		}
+
+		var decision *models.TurnDecision
+		err := o.invoke(models.RoleTeamLeader, leader, func() (string, error) {
+			var err error
+			decision, err = director.NextTurn(o.Discussion, candidates, maxTurns-taken)
+			if err != nil {
+				return "", err
+			}
+			if decision.Done {
+				return "Round done: " + decision.Reason, nil
+			}
+			return fmt.Sprintf("Next: %s — %s", decision.Role, decision.Reason), nil
+		})
+		if err != nil {
+			if err := o.recordFailure(models.RoleTeamLeader, leader.GetName(), fmt.Errorf("directing turns: %w", err)); err != nil {
+				return err
+			}
+			if taken == 0 {
+				o.notify("  ⚠️ The team leader could not direct this round; using the fixed order")
+				return o.runFixedTurns(round)
+			}
+			break
+		}
+		if decision.Done {
+			if taken == 0 {
+				o.notify("  ⚠️ The team leader ended the round before anyone spoke; using the fixed order")
+				return o.runFixedTurns(round)
+			}
+			o.notify(fmt.Sprintf("  🎙️  Leader: round done after %d turn(s) — %s", taken, o.truncate(decision.Reason, 150)))
+			break
+		}
+
+		next, ok := findCandidate(candidates, decision.Role)
+		if !ok {
+			// Hand the floor to the first candidate who hasn't spoken yet
+			for _, c := range candidates {
+				if !spoken[c.Role] {
+					next, ok = c, true
+					break
+				}
+			}
+			if !ok {
+				break
+			}
+			o.notify(fmt.Sprintf("  ⚠️ Leader chose %q, who cannot speak now; passing to %s", decision.Role, next.Name))
+			decision.Prompt, decision.Reason = "", ""
+		}
+		prompt := decision.Prompt
+		if prompt == "" {
+			prompt = next.Prompt
+		}
+
+		o.notify(fmt.Sprintf("  🎙️  Leader → %s: %s", next.Role, o.truncate(prompt, 150)))
+		direction := prompt
+		if decision.Reason != "" {
+			direction += " — " + decision.Reason
+		}
+		o.addMessage(string(models.RoleTeamLeader), string(next.Role), direction, "turn")
+
+		if err := o.runAgentContribution(next.Role, prompt); err != nil {
+			return err
+		}
+		spoken[next.Role] = true
+		taken++
+	}
+	if taken == maxTurns {
+		o.notify(fmt.Sprintf("  ⏱️  Turn cap reached (%d turns)", maxTurns))
+	}
+	return nil
+}
+
+// findCandidate returns the candidate with the given role.
+func findCandidate(candidates []models.TurnCandidate, role models.AgentRole) (models.TurnCandidate, bool) {
+	for _, c := range candidates {
+		if c.Role == role {
+			return c, true
+		}
+	}
+	return models.TurnCandidate{}, false
+}
@@ -0,0 +1,70 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/ai-agent-team/internal/llm"
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// TestFinalValidationDropsInterimScores runs an interim scoring pass that
+// scores every idea, then a final pass that skips one, and checks the
+// skipped idea is reported unscored instead of keeping its interim score.
+func TestFinalValidationDropsInterimScores(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Stream   bool `json:"stream"`
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var prompt strings.Builder
+		for _, m := range req.Messages {
+			prompt.WriteString(m.Content)
+		}
+
+		content := "Go with the best scored idea."
+		switch {
+		case strings.Contains(prompt.String(), "interim scores"):
+			content = `{"evaluations":[{"idea_id":"I1","score":5,"criteria_scores":{}},{"idea_id":"I2","score":9,"criteria_scores":{}}]}`
+		case strings.Contains(prompt.String(), "final scores"):
+			content = `{"evaluations":[{"idea_id":"I1","score":7,"criteria_scores":{}}]}`
+		}
+		writeChat(w, req.Stream, content)
+	}))
+	defer srv.Close()
+
+	o := NewConfigurableOrchestrator(&llm.BackendConfig{Backend: "openai", APIKey: "test", BaseURL: srv.URL, Model: "gpt-4o"}, models.DefaultTeamConfig())
+	o.OnProgress = func(string) {}
+	o.begin(&models.Discussion{Topic: "t", Ideas: []models.Idea{
+		{ID: "idea-one", Handle: "I1", Title: "Compost bins", Description: "shared bins"},
+		{ID: "idea-two", Handle: "I2", Title: "Surplus app", Description: "sell leftovers"},
+	}})
+
+	if scores, err := o.interimScores(); err != nil || scores["idea-two"] != 9 {
+		t.Fatalf("interim scores = %v (%v), want idea-two scored 9", scores, err)
+	}
+	if err := o.runFinalValidation(); err != nil {
+		t.Fatalf("runFinalValidation: %v", err)
+	}
+
+	d := o.Discussion
+	if fmt.Sprint(d.UnscoredIdeas) != "[idea-two]" {
+		t.Errorf("UnscoredIdeas = %v, want [idea-two]", d.UnscoredIdeas)
+	}
+	if skipped := d.Ideas[1]; skipped.Validated || skipped.Score != 0 {
+		t.Errorf("skipped idea kept its interim evaluation: validated %v, score %.1f", skipped.Validated, skipped.Score)
+	}
+	if d.FinalIdea == nil || d.FinalIdea.ID != "idea-one" {
+		t.Errorf("final idea = %+v, want idea-one", d.FinalIdea)
+	}
+}
@@ -20,6 +20,8 @@ const (
 	NodeIdea      NodeType = "idea"      // A runner-up idea
 	NodeResearch  NodeType = "research"  // A research finding
 	NodeImplement NodeType = "implement" // An implementation note
+	NodeAncestor  NodeType = "ancestor"  // An earlier version of an idea
+	NodeRisk      NodeType = "risk"      // A risk register entry
 )
 
 // ConceptMapNode is a single node in the concept map graph.
@@ -46,9 +48,9 @@ type ConceptMapData struct {
 }
 
 // BuildConceptMap extracts a concept map from a completed Discussion.
-// It builds nodes for the winning idea, its pros/cons, runner-up ideas,
-// key researcher findings, and implementer notes, then connects them with
-// labelled edges.
+// It builds nodes for the winning idea, its pros/cons and top risks, runner-up ideas,
+// earlier versions of the winning idea, key researcher findings, and
+// implementer notes, then connects them with labelled edges.
 func BuildConceptMap(d *models.Discussion) *ConceptMapData {
 	if d == nil {
 		return &ConceptMapData{Topic: "Unknown"}
@@ -64,11 +66,10 @@ func BuildConceptMap(d *models.Discussion) *ConceptMapData {
 	centerID := nextID()
 	centerLabel := d.Topic
 	centerDetail := ""
+	var centerIdea *models.Idea
 
 	if d.FinalIdea != nil {
-		centerLabel = d.FinalIdea.Title
-		centerDetail = d.FinalIdea.Description
-		data.Title = d.FinalIdea.Title
+		centerIdea = d.FinalIdea
 	} else if len(d.Ideas) > 0 {
 		best := d.Ideas[0]
 		for _, idea := range d.Ideas {
@@ -76,9 +77,18 @@ func BuildConceptMap(d *models.Discussion) *ConceptMapData {
 				best = idea
 			}
 		}
-		centerLabel = best.Title
-		centerDetail = best.Description
-		data.Title = best.Title
+		centerIdea = &best
+	}
+	if centerIdea != nil {
+		centerLabel = centerIdea.Title
+		centerDetail = centerIdea.Description
+		data.Title = centerIdea.Title
+	}
+
+	// ideaNodes maps idea IDs to their node IDs so lineage edges can be drawn
+	ideaNodes := make(map[string]string)
+	if centerIdea != nil {
+		ideaNodes[centerIdea.ID] = centerID
 	}
 
 	data.Nodes = append(data.Nodes, ConceptMapNode{
@@ -116,6 +126,25 @@ func BuildConceptMap(d *models.Discussion) *ConceptMapData {
 			})
 			data.Edges = append(data.Edges, ConceptMapEdge{Source: id, Target: centerID, Label: "challenges"})
 		}
+
+		// Highest-rated risks from the register → red risk nodes
+		for i, r := range models.SortRisks(d.FinalIdea.Risks) {
+			if i >= 4 {
+				break
+			}
+			detail := fmt.Sprintf("%s severity, %s likelihood (%s)", r.Severity, r.Likelihood, r.Category)
+			if r.Mitigation != "" {
+				detail += ". Mitigation: " + r.Mitigation
+			}
+			id := nextID()
+			data.Nodes = append(data.Nodes, ConceptMapNode{
+				ID:     id,
+				Label:  truncate(r.Description, 45),
+				Type:   NodeRisk,
+				Detail: detail,
+			})
+			data.Edges = append(data.Edges, ConceptMapEdge{Source: id, Target: centerID, Label: "threatens"})
+		}
 	}
 
 	// Runner-up ideas → purple idea nodes
@@ -135,9 +164,44 @@ func BuildConceptMap(d *models.Discussion) *ConceptMapData {
 			Detail: idea.Description,
 		})
 		data.Edges = append(data.Edges, ConceptMapEdge{Source: id, Target: centerID, Label: "alternative"})
+		ideaNodes[idea.ID] = id
 		added++
 	}
 
+	// Earlier versions of the winning idea → grey ancestor chain
+	evolved := make(map[string]bool) // "source>target" lineage edges already drawn
+	if centerIdea != nil {
+		child := centerID
+		for i := len(centerIdea.Revisions) - 1; i >= 0 && i >= len(centerIdea.Revisions)-3; i-- {
+			rev := centerIdea.Revisions[i]
+			id, ok := ideaNodes[rev.IdeaID]
+			if !ok {
+				id = nextID()
+				data.Nodes = append(data.Nodes, ConceptMapNode{
+					ID:     id,
+					Label:  truncate(fmt.Sprintf("v%d: %s", i+1, rev.Title), 40),
+					Type:   NodeAncestor,
+					Detail: fmt.Sprintf("Round %d: %s", rev.Round, rev.Description),
+				})
+				ideaNodes[rev.IdeaID] = id
+			}
+			data.Edges = append(data.Edges, ConceptMapEdge{Source: id, Target: child, Label: "evolved into"})
+			evolved[id+">"+child] = true
+			child = id
+		}
+	}
+
+	// Lineage between runner-ups already on the map
+	for _, idea := range d.Ideas {
+		id, ok := ideaNodes[idea.ID]
+		if !ok || id == centerID || len(idea.ParentIDs) == 0 {
+			continue
+		}
+		if parent, ok := ideaNodes[idea.ParentIDs[0]]; ok && parent != id && !evolved[parent+">"+id] {
+			data.Edges = append(data.Edges, ConceptMapEdge{Source: parent, Target: id, Label: "evolved into"})
+		}
+	}
+
 	// Researcher contributions → cyan research nodes (first sentence of each message)
 	researchAdded := 0
 	for _, msg := range d.Messages {
@@ -221,11 +285,14 @@ func RenderConceptMapHTML(data *ConceptMapData) string {
       idea:      {fill:"#7B68EE", r:26,  stroke:"#a89ff5"},
       research:  {fill:"#00D4FF", r:18,  stroke:"#66e3ff"},
       implement: {fill:"#FF8C42", r:22,  stroke:"#ffb07a"},
+      ancestor:  {fill:"#8B949E", r:20,  stroke:"#c9d1d9"},
+      risk:      {fill:"#E5534B", r:18,  stroke:"#f47067"},
     };
 
     var legendLabels = {
       center:"Winning Idea", pro:"Strength", con:"Challenge",
-      idea:"Alternative", research:"Research", implement:"Implementation"
+      idea:"Alternative", research:"Research", implement:"Implementation",
+      ancestor:"Earlier Version", risk:"Risk"
     };
 
     // Legend
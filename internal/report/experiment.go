@@ -0,0 +1,121 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// RenderExperimentHTML returns a standalone page comparing an experiment's
+// arms: outcome, scores, cost and duration of each, measured against the
+// first arm, and how far each pair of arms arrived at the same ideas.
+func RenderExperimentHTML(r *models.ExperimentResult) string {
+	if r == nil || len(r.Arms) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Experiment: %s</title></head>
+<body style="margin:0;background:#0d1117;">
+<section id="experiment" style="font-family:sans-serif;padding:40px 20px;background:#0d1117;color:#e6edf3;">
+  <h2 style="text-align:center;font-size:1.6rem;margin-bottom:4px;color:#fff;">🧪 Experiment: %s</h2>
+  <p style="text-align:center;color:#8b949e;font-size:0.85rem;margin-bottom:24px;">%d arms on the same topic, compared with %s. Token counts and costs are estimates.</p>
+  <p style="text-align:center;font-size:1rem;margin-bottom:24px;">%s</p>
+  <div style="max-width:1100px;margin:0 auto;overflow-x:auto;">
+  <table style="width:100%%;border-collapse:collapse;font-size:0.85rem;">
+    <thead><tr style="border-bottom:1px solid rgba(255,255,255,0.2);">
+      <th style="text-align:left;padding:8px;">Arm</th><th style="text-align:left;padding:8px;">Model</th><th style="padding:8px;">Agents</th><th style="padding:8px;">Rounds</th><th style="padding:8px;">Ideas</th><th style="padding:8px;">Mean score</th><th style="padding:8px;">Top score</th><th style="text-align:left;padding:8px;">Final idea</th><th style="padding:8px;">Final score</th><th style="padding:8px;">Cost</th><th style="padding:8px;">Tokens</th><th style="padding:8px;">Duration</th><th style="padding:8px;">Failures</th><th style="padding:8px;">Status</th></tr></thead>
+    <tbody>
+`, html.EscapeString(r.Topic), html.EscapeString(r.Topic), len(r.Arms), html.EscapeString(r.Arms[0].Name), html.EscapeString(ExperimentVerdict(r)))
+
+	for i, a := range r.Arms {
+		cost := fmt.Sprintf("$%.4f", a.Cost)
+		score := fmt.Sprintf("%.1f", a.FinalScore)
+		if i > 0 && a.CostRatio > 0 {
+			cost += fmt.Sprintf(" (%.1f×)", a.CostRatio)
+		}
+		if i > 0 && a.ScoreDelta != 0 {
+			score += fmt.Sprintf(" (%+.1f)", a.ScoreDelta)
+		}
+		status := html.EscapeString(a.Status)
+		if a.Error != "" {
+			status = fmt.Sprintf(`<span title="%s">%s</span>`, html.EscapeString(a.Error), status)
+		}
+		fmt.Fprintf(&b, `      <tr style="border-bottom:1px solid rgba(255,255,255,0.08);"><td style="padding:8px;">%s</td><td style="padding:8px;">%s</td>%s%s%s%s%s<td style="padding:8px;">%s</td>%s%s%s%s%s%s</tr>`+"\n",
+			html.EscapeString(a.Name), orDash(a.Model), metricCell(fmt.Sprint(a.Agents)), metricCell(fmt.Sprint(a.Rounds)),
+			metricCell(fmt.Sprint(a.Ideas)), metricCell(fmt.Sprintf("%.1f", a.MeanScore)), metricCell(fmt.Sprintf("%.1f", a.TopScore)),
+			orDash(a.FinalIdea), metricCell(score), metricCell(cost), metricCell(fmt.Sprint(a.Tokens)),
+			metricCell(formatDuration(a.Duration)), metricCell(fmt.Sprint(a.Failures)), metricCell(status))
+	}
+	b.WriteString("    </tbody>\n  </table>\n")
+
+	if len(r.Overlaps) > 0 {
+		b.WriteString(`  <h3 style="font-size:1.1rem;margin:32px 0 8px;color:#fff;">Idea overlap</h3>
+  <table style="width:100%;border-collapse:collapse;font-size:0.85rem;">
+    <thead><tr style="border-bottom:1px solid rgba(255,255,255,0.2);">
+      <th style="text-align:left;padding:8px;">Arms</th><th style="padding:8px;">Shared ideas</th><th style="padding:8px;">Overlap</th><th style="padding:8px;">Same final idea</th><th style="text-align:left;padding:8px;">Matched ideas</th></tr></thead>
+    <tbody>
+`)
+		for _, o := range r.Overlaps {
+			same := "no"
+			if o.SameFinal {
+				same = "yes"
+			}
+			var matches []string
+			for _, m := range o.Matches {
+				matches = append(matches, fmt.Sprintf("%s ↔ %s", html.EscapeString(m.A), html.EscapeString(m.B)))
+			}
+			fmt.Fprintf(&b, `      <tr style="border-bottom:1px solid rgba(255,255,255,0.08);"><td style="padding:8px;">%s vs %s</td>%s%s%s<td style="padding:8px;">%s</td></tr>`+"\n",
+				html.EscapeString(o.A), html.EscapeString(o.B), metricCell(fmt.Sprint(o.Shared)),
+				metricCell(fmt.Sprintf("%.0f%%", o.Similarity*100)), metricCell(same), matchList(matches))
+		}
+		b.WriteString("    </tbody>\n  </table>\n")
+	}
+	b.WriteString("  </div>\n</section>\n</body>\n</html>\n")
+	return b.String()
+}
+
+// matchList joins escaped idea matches, one per line
+func matchList(matches []string) string {
+	if len(matches) == 0 {
+		return orDash("")
+	}
+	return strings.Join(matches, "<br>")
+}
+
+// ExperimentVerdict sums up each arm against the first in one line, e.g.
+// "full: 3.2× the cost of standard, +0.8 final score, picks a different idea."
+func ExperimentVerdict(r *models.ExperimentResult) string {
+	if r == nil || len(r.Arms) < 2 {
+		return ""
+	}
+	base := r.Arms[0]
+	if base.Status != "completed" {
+		return fmt.Sprintf("Baseline %s did not complete; arms cannot be compared against it.", base.Name)
+	}
+	var parts []string
+	for _, a := range r.Arms[1:] {
+		if a.Status != "completed" {
+			parts = append(parts, fmt.Sprintf("%s did not complete", a.Name))
+			continue
+		}
+		cost := "cost not measured"
+		if a.CostRatio > 0 {
+			cost = fmt.Sprintf("%.1f× the cost of %s", a.CostRatio, base.Name)
+		}
+		pick := "picks a different idea"
+		for _, o := range r.Overlaps {
+			if (o.A == base.Name && o.B == a.Name) || (o.A == a.Name && o.B == base.Name) {
+				if o.SameFinal {
+					pick = "picks the same idea"
+				}
+			}
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s, %+.1f final score, %s", a.Name, cost, a.ScoreDelta, pick))
+	}
+	return strings.Join(parts, "; ") + "."
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// RenderMetricsHTML returns an appendix with the duration, calls, estimated
+// tokens and cost of each phase, and the same totals per agent role, slowest
+// first. It returns "" when no metrics were recorded.
+func RenderMetricsHTML(d *models.Discussion) string {
+	if d == nil || d.Metrics == nil || len(d.Metrics.Phases) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(`
+<section id="run-metrics" style="font-family:sans-serif;padding:40px 20px;background:#0d1117;color:#e6edf3;page-break-before:always;">
+  <h2 style="text-align:center;font-size:1.6rem;margin-bottom:4px;color:#fff;">⏱️ Appendix: Run Metrics</h2>
+  <p style="text-align:center;color:#8b949e;font-size:0.85rem;margin-bottom:24px;">Time and estimated spend of each phase and agent. Token counts and costs are estimates.</p>
+  <div style="max-width:960px;margin:0 auto;overflow-x:auto;">
+  <table style="width:100%;border-collapse:collapse;font-size:0.85rem;">
+    <thead><tr style="border-bottom:1px solid rgba(255,255,255,0.2);">
+      <th style="text-align:left;padding:8px;">Phase</th><th style="padding:8px;">Round</th><th style="padding:8px;">Duration</th><th style="padding:8px;">Calls</th><th style="padding:8px;">Tokens</th><th style="padding:8px;">Cost</th><th style="padding:8px;">Errors</th><th style="padding:8px;">Outcome</th></tr></thead>
+    <tbody>
+`)
+	for _, p := range d.Metrics.Phases {
+		round := orDash("")
+		if p.Round > 0 {
+			round = fmt.Sprint(p.Round)
+		}
+		fmt.Fprintf(&b, `      <tr style="border-bottom:1px solid rgba(255,255,255,0.08);"><td style="padding:8px;">%s</td>%s%s%s%s%s%s%s</tr>`+"\n",
+			html.EscapeString(p.Label), metricCell(round), metricCell(formatDuration(p.Duration)),
+			metricCell(fmt.Sprint(p.Calls)), metricCell(fmt.Sprint(p.Tokens)), metricCell(fmt.Sprintf("$%.4f", p.Cost)),
+			metricCell(fmt.Sprint(p.Errors)), metricCell(html.EscapeString(p.Outcome)))
+	}
+	b.WriteString(`    </tbody>
+  </table>
+  <table style="width:100%;border-collapse:collapse;font-size:0.85rem;margin-top:24px;">
+    <thead><tr style="border-bottom:1px solid rgba(255,255,255,0.2);">
+      <th style="text-align:left;padding:8px;">Agent</th><th style="text-align:left;padding:8px;">Model</th><th style="padding:8px;">Time</th><th style="padding:8px;">Calls</th><th style="padding:8px;">Tokens</th><th style="padding:8px;">Cost</th><th style="padding:8px;">Retries</th><th style="padding:8px;">Errors</th></tr></thead>
+    <tbody>
+`)
+	for _, r := range d.Metrics.ByRole() {
+		fmt.Fprintf(&b, `      <tr style="border-bottom:1px solid rgba(255,255,255,0.08);"><td style="padding:8px;">%s</td><td style="padding:8px;">%s</td>%s%s%s%s%s%s</tr>`+"\n",
+			html.EscapeString(r.Role), orDash(r.Model), metricCell(formatDuration(r.Duration)),
+			metricCell(fmt.Sprint(r.Calls)), metricCell(fmt.Sprint(r.Tokens)), metricCell(fmt.Sprintf("$%.4f", r.Cost)),
+			metricCell(fmt.Sprint(r.Retries)), metricCell(fmt.Sprint(r.Errors)))
+	}
+	b.WriteString("    </tbody>\n  </table>\n  </div>\n</section>\n")
+	return b.String()
+}
+
+// metricCell renders a centered table cell; content must already be escaped.
+func metricCell(content string) string {
+	return fmt.Sprintf(`<td style="text-align:center;padding:8px;">%s</td>`, content)
+}
+
+// formatDuration rounds d for display.
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
@@ -0,0 +1,141 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// phaseColors cycles through bar colors for the plan timeline.
+var phaseColors = []string{"#7B68EE", "#00D4FF", "#51E898", "#FF8C42", "#FFD93D", "#FF6B6B"}
+
+// RenderPlanHTML returns an HTML section showing the final idea's
+// implementation plan: a phase timeline, the tasks of each phase, the roles
+// needed and the success metrics. It returns "" when there is no plan.
+func RenderPlanHTML(d *models.Discussion) string {
+	if d == nil || d.FinalIdea == nil || d.FinalIdea.Plan == nil {
+		return ""
+	}
+	plan := d.FinalIdea.Plan
+	total := plan.TotalWeeks()
+	if total <= 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(`
+<section id="implementation-plan" style="font-family:sans-serif;padding:40px 20px;background:#0d1117;color:#e6edf3;page-break-before:always;">
+  <h2 style="text-align:center;font-size:1.6rem;margin-bottom:4px;color:#fff;">🗓️ Implementation Plan</h2>
+`)
+	fmt.Fprintf(&b, `  <p style="text-align:center;color:#8b949e;font-size:0.85rem;margin-bottom:24px;">%s — %d phases, %d tasks, %.0f weeks, %.0f person-days</p>`+"\n",
+		html.EscapeString(plan.Summary), len(plan.Phases), len(plan.Tasks()), total, plan.TotalEffortDays())
+	b.WriteString(`  <div style="max-width:960px;margin:0 auto;">` + "\n")
+
+	// Timeline: one bar per phase, positioned by its scheduled weeks
+	for i, ph := range plan.Phases {
+		left := ph.StartWeek / total * 100
+		width := ph.DurationWeeks / total * 100
+		fmt.Fprintf(&b, `    <div style="display:flex;align-items:center;gap:12px;margin:6px 0;font-size:0.8rem;"><div style="width:180px;flex-shrink:0;">%s</div><div style="position:relative;flex:1;height:22px;background:#161b22;border-radius:4px;"><div title="%s" style="position:absolute;left:%.1f%%;width:%.1f%%;height:100%%;background:%s;border-radius:4px;padding:2px 6px;box-sizing:border-box;color:#0d1117;white-space:nowrap;overflow:hidden;">wk %.0f–%.0f</div></div></div>`+"\n",
+			html.EscapeString(ph.Name), html.EscapeString(ph.Milestone), left, width, phaseColors[i%len(phaseColors)], ph.StartWeek, ph.StartWeek+ph.DurationWeeks)
+	}
+
+	b.WriteString(`    <table style="width:100%;border-collapse:collapse;font-size:0.85rem;margin-top:24px;">
+      <thead><tr style="border-bottom:1px solid rgba(255,255,255,0.2);"><th style="text-align:left;padding:8px;">Phase</th><th style="text-align:left;padding:8px;">Task</th><th style="padding:8px;">Effort (days)</th><th style="text-align:left;padding:8px;">Role</th><th style="text-align:left;padding:8px;">Depends on</th></tr></thead>
+      <tbody>
+`)
+	for _, ph := range plan.Phases {
+		for _, t := range ph.Tasks {
+			fmt.Fprintf(&b, `        <tr style="border-bottom:1px solid rgba(255,255,255,0.08);"><td style="padding:8px;">%s</td><td style="padding:8px;">%s</td><td style="text-align:center;padding:8px;">%.1f</td><td style="padding:8px;">%s</td><td style="padding:8px;">%s</td></tr>`+"\n",
+				html.EscapeString(ph.Name), html.EscapeString(t.Title), t.EffortDays, orDash(t.Role), orDash(strings.Join(t.DependsOn, ", ")))
+		}
+	}
+	b.WriteString("      </tbody>\n    </table>\n")
+
+	if len(plan.Roles) > 0 {
+		fmt.Fprintf(&b, `    <p style="margin-top:20px;font-size:0.85rem;"><strong>Roles needed:</strong> %s</p>`+"\n", html.EscapeString(strings.Join(plan.Roles, ", ")))
+	}
+	if len(plan.SuccessMetrics) > 0 {
+		b.WriteString(`    <p style="margin-top:12px;font-size:0.85rem;"><strong>Success metrics:</strong></p>` + "\n    <ul style=\"font-size:0.85rem;\">\n")
+		for _, m := range plan.SuccessMetrics {
+			fmt.Fprintf(&b, "      <li>%s: %s</li>\n", html.EscapeString(m.Name), html.EscapeString(m.Target))
+		}
+		b.WriteString("    </ul>\n")
+	}
+	b.WriteString("  </div>\n</section>\n")
+	return b.String()
+}
+
+// PlanToMermaidGantt exports the plan as a Mermaid gantt chart starting on
+// the given date, one section per phase with its tasks laid out one after
+// another from the phase start and a milestone at the phase end.
+func PlanToMermaidGantt(title string, plan *models.ImplementationPlan, start time.Time) string {
+	day := func(offset float64) string {
+		return start.AddDate(0, 0, int(offset)).Format("2006-01-02")
+	}
+
+	var b strings.Builder
+	b.WriteString("gantt\n")
+	fmt.Fprintf(&b, "    title %s\n", mermaidText(title))
+	b.WriteString("    dateFormat YYYY-MM-DD\n")
+	for _, ph := range plan.Phases {
+		fmt.Fprintf(&b, "    section %s\n", mermaidText(ph.Name))
+		offset := ph.StartWeek * 7
+		for _, t := range ph.Tasks {
+			days := max(t.EffortDays, 1)
+			fmt.Fprintf(&b, "    %s :%s, %s, %.0fd\n", mermaidText(t.Title), mermaidID(t.ID), day(offset), days)
+			offset += days
+		}
+		if ph.Milestone != "" {
+			fmt.Fprintf(&b, "    %s :milestone, %s, 0d\n", mermaidText(ph.Milestone), day((ph.StartWeek+ph.DurationWeeks)*7))
+		}
+	}
+	return b.String()
+}
+
+// mermaidText strips characters that break Mermaid gantt lines.
+func mermaidText(s string) string {
+	return strings.NewReplacer(":", " -", "#", "", ";", ",", "\n", " ").Replace(strings.TrimSpace(s))
+}
+
+// mermaidID makes a task ID safe to use as a Mermaid task ID.
+func mermaidID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == ':' || r == ',' {
+			return '_'
+		}
+		return r
+	}, id)
+}
+
+// PlanToIssuesCSV exports the plan's tasks as CSV rows for importing into an
+// issue tracker: title, body, labels, milestone, estimate and dependencies.
+func PlanToIssuesCSV(plan *models.ImplementationPlan) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"id", "title", "body", "labels", "milestone", "estimate_days", "depends_on"}}
+	for _, ph := range plan.Phases {
+		milestone := ph.Name
+		if ph.Milestone != "" {
+			milestone += ": " + ph.Milestone
+		}
+		for _, t := range ph.Tasks {
+			labels := []string{"phase:" + ph.ID}
+			if t.Role != "" {
+				labels = append(labels, "role:"+t.Role)
+			}
+			rows = append(rows, []string{
+				t.ID, t.Title, t.Description, strings.Join(labels, ","), milestone,
+				fmt.Sprintf("%g", t.EffortDays), strings.Join(t.DependsOn, ","),
+			})
+		}
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to write issues CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
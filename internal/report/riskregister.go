@@ -0,0 +1,97 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// maxRegisterRisks caps the number of risks shown in the risk register.
+const maxRegisterRisks = 20
+
+// riskColors maps a risk level to its badge color.
+var riskColors = map[string]string{
+	models.RiskHigh:   "#E5534B",
+	models.RiskMedium: "#FFD93D",
+	models.RiskLow:    "#51E898",
+}
+
+// registerRow is one risk in the rendered register with the idea it targets.
+type registerRow struct {
+	idea  string
+	final bool
+	risk  models.Risk
+}
+
+// RenderRiskRegisterHTML returns an HTML section listing the risks the critic
+// recorded against each idea, winning idea first and then by severity ×
+// likelihood. It returns "" when no idea has risks.
+func RenderRiskRegisterHTML(d *models.Discussion) string {
+	if d == nil {
+		return ""
+	}
+
+	var rows []registerRow
+	for _, idea := range d.Ideas {
+		final := d.FinalIdea != nil && idea.ID == d.FinalIdea.ID
+		for _, r := range idea.Risks {
+			rows = append(rows, registerRow{idea: idea.Title, final: final, risk: r})
+		}
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].final != rows[j].final {
+			return rows[i].final
+		}
+		return rows[i].risk.Rating() > rows[j].risk.Rating()
+	})
+	if len(rows) > maxRegisterRisks {
+		rows = rows[:maxRegisterRisks]
+	}
+
+	var b strings.Builder
+	b.WriteString(`
+<section id="risk-register" style="font-family:sans-serif;padding:40px 20px;background:#0d1117;color:#e6edf3;page-break-before:always;">
+  <h2 style="text-align:center;font-size:1.6rem;margin-bottom:4px;color:#fff;">📋 Risk Register</h2>
+  <p style="text-align:center;color:#8b949e;font-size:0.85rem;margin-bottom:24px;">Risks raised by the critic, with severity, likelihood and mitigation.</p>
+  <div style="max-width:960px;margin:0 auto;overflow-x:auto;">
+  <table style="width:100%;border-collapse:collapse;font-size:0.85rem;">
+    <thead><tr style="border-bottom:1px solid rgba(255,255,255,0.2);">
+      <th style="text-align:left;padding:8px;">Idea</th><th style="text-align:left;padding:8px;">Risk</th><th style="padding:8px;">Severity</th><th style="padding:8px;">Likelihood</th><th style="text-align:left;padding:8px;">Mitigation</th><th style="text-align:left;padding:8px;">Open question</th></tr></thead>
+    <tbody>
+`)
+	for _, row := range rows {
+		title := html.EscapeString(row.idea)
+		if row.final {
+			title = "⭐ " + title
+		}
+		r := row.risk
+		riskCell := html.EscapeString(r.Description)
+		if r.Category != "" {
+			riskCell = fmt.Sprintf(`<span style="color:#8b949e;">%s:</span> %s`, html.EscapeString(r.Category), riskCell)
+		}
+		fmt.Fprintf(&b, `      <tr style="border-bottom:1px solid rgba(255,255,255,0.08);"><td style="padding:8px;">%s</td><td style="padding:8px;">%s</td>%s%s<td style="padding:8px;">%s</td><td style="padding:8px;">%s</td></tr>`+"\n",
+			title, riskCell, riskBadge(r.Severity), riskBadge(r.Likelihood),
+			orDash(r.Mitigation), orDash(r.OpenQuestion))
+	}
+	b.WriteString("    </tbody>\n  </table>\n  </div>\n</section>\n")
+	return b.String()
+}
+
+// riskBadge renders a colored table cell for a risk level.
+func riskBadge(level string) string {
+	return fmt.Sprintf(`<td style="text-align:center;padding:8px;color:%s;font-weight:700;">%s</td>`, riskColors[level], html.EscapeString(level))
+}
+
+// orDash escapes s, or returns a dash when it is empty.
+func orDash(s string) string {
+	if s == "" {
+		return `<span style="color:#8b949e;">–</span>`
+	}
+	return html.EscapeString(s)
+}
@@ -0,0 +1,110 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// maxTableIdeas caps the number of ideas shown in the score table.
+const maxTableIdeas = 8
+
+// RenderScoreTableHTML returns an HTML section comparing the top ideas on
+// each scoring criterion, built from the scores recorded on the discussion.
+// Ideas final validation left unscored are listed beneath the table.
+// It returns "" when no idea has per-criterion scores.
+func RenderScoreTableHTML(d *models.Discussion) string {
+	if d == nil {
+		return ""
+	}
+
+	var ideas []models.Idea
+	for _, idea := range d.Ideas {
+		if len(idea.CriterionScores) > 0 {
+			ideas = append(ideas, idea)
+		}
+	}
+	if len(ideas) == 0 {
+		return ""
+	}
+	sort.SliceStable(ideas, func(i, j int) bool { return ideas[i].Score > ideas[j].Score })
+	if len(ideas) > maxTableIdeas {
+		ideas = ideas[:maxTableIdeas]
+	}
+
+	criteria := d.Criteria
+	if len(criteria) == 0 {
+		criteria = models.DefaultCriteria()
+	}
+	showRating := d.Tournament != nil
+
+	var b strings.Builder
+	b.WriteString(`
+<section id="score-table" style="font-family:sans-serif;padding:40px 20px;background:#0d1117;color:#e6edf3;page-break-before:always;">
+  <h2 style="text-align:center;font-size:1.6rem;margin-bottom:4px;color:#fff;">📊 Comparative Scores</h2>
+  <p style="text-align:center;color:#8b949e;font-size:0.85rem;margin-bottom:24px;">Per-criterion scores recorded during validation; overall is the weighted mean.</p>
+  <div style="max-width:960px;margin:0 auto;overflow-x:auto;">
+  <table style="width:100%;border-collapse:collapse;font-size:0.85rem;">
+    <thead><tr style="border-bottom:1px solid rgba(255,255,255,0.2);">
+      <th style="text-align:left;padding:8px;">Idea</th>`)
+	for _, c := range criteria {
+		fmt.Fprintf(&b, `<th style="padding:8px;">%s<br><span style="color:#8b949e;font-weight:400;">×%.1f</span></th>`, html.EscapeString(c.Name), c.Weight)
+	}
+	b.WriteString(`<th style="padding:8px;">Overall</th>`)
+	if showRating {
+		b.WriteString(`<th style="padding:8px;">Rating</th>`)
+	}
+	b.WriteString("</tr></thead>\n    <tbody>\n")
+
+	for _, idea := range ideas {
+		title := html.EscapeString(idea.Title)
+		if d.FinalIdea != nil && idea.ID == d.FinalIdea.ID {
+			title = "⭐ " + title
+		}
+		if idea.Disputed {
+			title += ` <span title="Judges disagreed strongly" style="color:#FFD93D;">⚠️</span>`
+		}
+		fmt.Fprintf(&b, `      <tr style="border-bottom:1px solid rgba(255,255,255,0.08);"><td style="padding:8px;">%s</td>`, title)
+		for _, c := range criteria {
+			if s, ok := idea.CriterionScores[c.Name]; ok {
+				fmt.Fprintf(&b, `<td style="text-align:center;padding:8px;">%.1f</td>`, s)
+			} else {
+				b.WriteString(`<td style="text-align:center;padding:8px;color:#8b949e;">–</td>`)
+			}
+		}
+		fmt.Fprintf(&b, `<td style="text-align:center;padding:8px;font-weight:700;">%.1f</td>`, idea.Score)
+		if showRating {
+			if idea.Rating > 0 {
+				fmt.Fprintf(&b, `<td style="text-align:center;padding:8px;">%.0f</td>`, idea.Rating)
+			} else {
+				b.WriteString(`<td style="text-align:center;padding:8px;color:#8b949e;">–</td>`)
+			}
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("    </tbody>\n  </table>\n")
+	if unscored := unscoredTitles(d); len(unscored) > 0 {
+		fmt.Fprintf(&b, `  <p style="color:#FFD93D;font-size:0.8rem;margin-top:12px;">⚠️ Not scored by the evaluation: %s</p>`+"\n",
+			html.EscapeString(strings.Join(unscored, ", ")))
+	}
+	b.WriteString("  </div>\n</section>\n")
+	return b.String()
+}
+
+// unscoredTitles returns the titles of the ideas recorded as unscored.
+func unscoredTitles(d *models.Discussion) []string {
+	unscored := make(map[string]bool, len(d.UnscoredIdeas))
+	for _, id := range d.UnscoredIdeas {
+		unscored[id] = true
+	}
+	var titles []string
+	for _, idea := range d.Ideas {
+		if unscored[idea.ID] {
+			titles = append(titles, idea.Title)
+		}
+	}
+	return titles
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/yourusername/ai-agent-team/internal/models"
+)
+
+// RenderSummaryHTML returns an HTML section with the executive summary and a
+// round-by-round timeline of the leader's round summaries: insights, ideas
+// added and set aside, and the focus chosen for the next round. It returns ""
+// when there is neither a summary nor any round summaries.
+func RenderSummaryHTML(d *models.Discussion) string {
+	if d == nil || (d.Summary == "" && len(d.RoundSummaries) == 0) {
+		return ""
+	}
+
+	titles := make(map[string]string, len(d.Ideas))
+	for _, idea := range d.Ideas {
+		titles[idea.ID] = idea.Title
+	}
+	ideaList := func(ids []string) string {
+		var names []string
+		for _, id := range ids {
+			if t, ok := titles[id]; ok {
+				names = append(names, t)
+			}
+		}
+		return orDash(strings.Join(names, ", "))
+	}
+
+	var b strings.Builder
+	b.WriteString(`
+<section id="executive-summary" style="font-family:sans-serif;padding:40px 20px;background:#0d1117;color:#e6edf3;page-break-before:always;">
+  <h2 style="text-align:center;font-size:1.6rem;margin-bottom:16px;color:#fff;">📝 Executive Summary</h2>
+  <div style="max-width:960px;margin:0 auto;">
+`)
+	if d.Summary != "" {
+		fmt.Fprintf(&b, `    <p style="font-size:1rem;line-height:1.6;border-left:4px solid #7B68EE;padding-left:16px;">%s</p>`+"\n", html.EscapeString(d.Summary))
+	}
+
+	for _, s := range d.RoundSummaries {
+		fmt.Fprintf(&b, `    <div style="margin-top:20px;padding:12px 16px;background:#161b22;border-radius:6px;font-size:0.85rem;">`+"\n")
+		fmt.Fprintf(&b, `      <h3 style="margin:0 0 8px;font-size:1rem;color:#00D4FF;">Round %d</h3>`+"\n", s.Round)
+		if len(s.KeyInsights) > 0 {
+			b.WriteString("      <ul style=\"margin:0 0 8px;\">\n")
+			for _, insight := range s.KeyInsights {
+				fmt.Fprintf(&b, "        <li>%s</li>\n", html.EscapeString(insight))
+			}
+			b.WriteString("      </ul>\n")
+		}
+		fmt.Fprintf(&b, "      <p style=\"margin:4px 0;\"><strong>Ideas added:</strong> %s</p>\n", ideaList(s.IdeasAdded))
+		if len(s.IdeasDropped) > 0 {
+			fmt.Fprintf(&b, "      <p style=\"margin:4px 0;\"><strong>Set aside:</strong> %s</p>\n", ideaList(s.IdeasDropped))
+		}
+		if s.Focus != "" {
+			fmt.Fprintf(&b, "      <p style=\"margin:4px 0;\"><strong>Next focus:</strong> %s</p>\n", html.EscapeString(s.Focus))
+		}
+		b.WriteString("    </div>\n")
+	}
+	b.WriteString("  </div>\n</section>\n")
+	return b.String()
+}
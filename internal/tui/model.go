@@ -28,7 +28,7 @@ var botIdleQuips = []string{
 type AgentState struct {
 	Role      string
 	Name      string
-	Status    string // "idle", "working", "complete"
+	Status    string // "idle", "working", "complete", "failed"
 	Message   string
 	Speech    string // Latest contribution text (truncated for bubble)
 	Model     string // LLM model identifier
@@ -49,7 +49,6 @@ type Model struct {
 	CurrentPhase    string
 	CurrentRound    int
 	TotalRounds     int
-	PhaseProgress   float64
 	OverallProgress float64
 
 	// Progress bars
@@ -58,6 +57,13 @@ type Model struct {
 	// Ideas generated
 	Ideas []*models.Idea
 
+	// Leader's focus for the next round and the final executive summary
+	Focus   string
+	Summary string
+
+	// Failed agent contributions by cause
+	Failures string
+
 	// Messages
 	Messages    []string
 	MaxMessages int
@@ -81,7 +87,7 @@ type Model struct {
 type ProgressMsg struct {
 	Phase    string
 	Round    int
-	Progress float64
+	Progress float64 // fraction of the whole discussion done, 0-1
 }
 
 // AgentUpdateMsg is sent when an agent status changes
@@ -97,6 +103,11 @@ type IdeaGeneratedMsg struct {
 	Idea *models.Idea
 }
 
+// IdeaScoredMsg is sent when the moderator scores an idea
+type IdeaScoredMsg struct {
+	Idea *models.Idea
+}
+
 // LogMsg is sent to add a log message
 type LogMsg string
 
@@ -112,6 +123,16 @@ type AgentChunkMsg struct {
 	Chunk string
 }
 
+// RoundSummaryMsg is sent when the leader has summarized a round
+type RoundSummaryMsg struct {
+	Summary *models.RoundSummary
+}
+
+// SnapshotMsg is sent with a snapshot of the discussion after each change
+type SnapshotMsg struct {
+	Discussion *models.Discussion
+}
+
 // CompleteMsg is sent when discussion completes
 type CompleteMsg struct {
 	Discussion *models.Discussion
@@ -136,9 +157,14 @@ func NewModel(config *models.TeamConfig, topic string) Model {
 		s.Spinner = spinner.Moon
 		s.Style = spinnerStyle
 
+		name := getPersona(string(role)).Name
+		if cr, ok := config.CustomRole(role); ok {
+			name = cr.Name
+		}
+
 		agents[string(role)] = &AgentState{
 			Role:    string(role),
-			Name:    getPersona(string(role)).Name,
+			Name:    name,
 			Status:  "idle",
 			Spinner: s,
 		}
@@ -147,12 +173,15 @@ func NewModel(config *models.TeamConfig, topic string) Model {
 	// Create progress bar
 	prog := progress.New(progress.WithDefaultGradient())
 
+	// Adaptive mode may run up to the round cap
+	_, totalRounds := config.RoundLimits()
+
 	return Model{
 		TeamConfig:   config,
 		Topic:        topic,
 		Agents:       agents,
 		CurrentPhase: "Initializing",
-		TotalRounds:  config.MaxRounds,
+		TotalRounds:  totalRounds,
 		ProgressBar:  prog,
 		Ideas:        []*models.Idea{},
 		Messages:     []string{},
@@ -202,8 +231,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ProgressMsg:
 		m.CurrentPhase = msg.Phase
 		m.CurrentRound = msg.Round
-		m.PhaseProgress = msg.Progress
-		m.OverallProgress = (float64(msg.Round-1) + msg.Progress) / float64(m.TotalRounds)
+		m.OverallProgress = msg.Progress
 		return m, nil
 
 	case AgentUpdateMsg:
@@ -223,7 +251,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case IdeaGeneratedMsg:
 		m.Ideas = append(m.Ideas, msg.Idea)
-		m.TotalIdeas++
+		return m, nil
+
+	case SnapshotMsg:
+		// The snapshot's idea list reflects merged duplicates
+		m.TotalIdeas = len(msg.Discussion.Ideas)
+		m.Failures = msg.Discussion.FailureSummary()
+		return m, nil
+
+	case IdeaScoredMsg:
+		for i, idea := range m.Ideas {
+			if idea.ID == msg.Idea.ID {
+				m.Ideas[i] = msg.Idea
+			}
+		}
 		return m, nil
 
 	case LogMsg:
@@ -247,8 +288,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case RoundSummaryMsg:
+		if msg.Summary.Focus != "" {
+			m.Focus = msg.Summary.Focus
+		}
+		return m, nil
+
 	case CompleteMsg:
 		m.Status = "complete"
+		if msg.Discussion != nil {
+			m.Summary = msg.Discussion.Summary
+			m.Failures = msg.Discussion.FailureSummary()
+		}
+		m.OverallProgress = 1
 		m.EndTime = time.Now()
 		// Auto-exit after a brief pause so user can see final state
 		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
@@ -289,6 +341,11 @@ func (m Model) View() string {
 		sections = append(sections, m.renderIdeas())
 	}
 
+	// Executive summary once the run is done
+	if m.Status == "complete" && m.Summary != "" {
+		sections = append(sections, m.renderSummary())
+	}
+
 	// Status bar
 	sections = append(sections, m.renderStatus())
 
@@ -323,9 +380,22 @@ func (m Model) renderProgress() string {
 	header := lipgloss.JoinHorizontal(lipgloss.Left, phaseText, "  ", roundText)
 	prog := lipgloss.JoinHorizontal(lipgloss.Left, "  ", progressBar, " ", progressPercent)
 
+	if m.Focus != "" && m.Status != "complete" {
+		focus := systemMessageStyle.Render("  🧭 Focus: " + truncateText(m.Focus, m.Width-14, 1))
+		return lipgloss.JoinVertical(lipgloss.Left, header, prog, focus)
+	}
 	return lipgloss.JoinVertical(lipgloss.Left, header, prog)
 }
 
+func (m Model) renderSummary() string {
+	width := m.Width - 8
+	if width < 40 {
+		width = 40
+	}
+	title := lipgloss.NewStyle().Foreground(neonMint).Bold(true).Render("📝 Executive Summary")
+	return lipgloss.JoinHorizontal(lipgloss.Top, "  ", summaryBoxStyle.Copy().Width(width).Render(title+"\n\n"+m.Summary))
+}
+
 func (m Model) renderWarRoom() string {
 	roles := m.TeamConfig.GetActiveAgentRoles()
 
@@ -372,6 +442,8 @@ func (m Model) renderAgentCard(agent *AgentState, width int) string {
 		statusIndicator = agent.Spinner.View()
 	case "complete":
 		statusIndicator = checkmarkStyle.Render("✓")
+	case "failed":
+		statusIndicator = lipgloss.NewStyle().Foreground(coral).Bold(true).Render("✗")
 	default:
 		statusIndicator = lipgloss.NewStyle().Foreground(robotGray).Render("○")
 	}
@@ -399,6 +471,8 @@ func (m Model) renderAgentCard(agent *AgentState, width int) string {
 		} else {
 			speechContent = lipgloss.NewStyle().Foreground(neonMint).Render("🙌 High-five!")
 		}
+	case "failed":
+		speechContent = lipgloss.NewStyle().Foreground(coral).Render(truncateText(agent.Speech, bubbleWidth, bubbleLines))
 	default:
 		speechContent = lipgloss.NewStyle().Foreground(robotGray).Italic(true).Render("😴 " + persona.Tagline + "...")
 	}
@@ -493,6 +567,9 @@ func (m Model) renderStatus() string {
 	case "complete":
 		duration := m.EndTime.Sub(m.StartTime)
 		statusLine = statusCompleteStyle.Render(fmt.Sprintf("  🙌 Bots nailed it! (%s)", formatDuration(duration)))
+		if m.Failures != "" {
+			statusLine += statusErrorStyle.Render(fmt.Sprintf("  ⚠️ Failed contributions: %s", m.Failures))
+		}
 	case "error":
 		statusLine = statusErrorStyle.Render(fmt.Sprintf("  ❌ Error: %s", m.ErrorMessage))
 	}
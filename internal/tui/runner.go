@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -55,218 +56,156 @@ func runDiscussion(p *tea.Program, cfg *llm.BackendConfig, config *models.TeamCo
 	// Create orchestrator with BackendConfig for per-agent model selection
 	orch := orchestrator.NewConfigurableOrchestrator(cfg, config)
 
-	// Wire streaming chunks directly to TUI agent speech bubbles
-	orch.OnChunk = func(role, chunk string) {
-		p.Send(AgentChunkMsg{Role: role, Chunk: chunk})
-	}
-
-	// Set up progress callback to send updates to TUI
+	// Human-readable log lines feed the transmissions counter
 	orch.OnProgress = func(message string) {
 		p.Send(LogMsg(message))
-
-		// Detect phase changes
-		if strings.Contains(message, "Phase") {
-			phase := extractPhase(message)
-			round := extractRound(message)
-			p.Send(ProgressMsg{
-				Phase:    phase,
-				Round:    round,
-				Progress: 0.1,
-			})
-		}
-
-		// Detect agent speech (📣 [role] content) — clears streaming buffer, sets final text
-		if strings.Contains(message, "📣 [") {
-			role, speech := extractSpeech(message)
-			if role != "" {
-				p.Send(AgentUpdateMsg{
-					Role:    role,
-					Status:  "working",
-					Message: "Just spoke",
-					Speech:  speech,
-				})
-			}
-		}
-
-		// Detect agent starting — clear speech buffer so streaming fills it fresh
-		if strings.Contains(message, "contributing") || strings.Contains(message, "working") {
-			role := extractAgentRole(message)
-			if role != "" {
-				p.Send(AgentUpdateMsg{
-					Role:    role,
-					Status:  "working",
-					Message: extractAgentMessage(message),
-					Speech:  "", // Clear for streaming
-				})
-			}
-		}
-
-		// Detect model assignments (🔧 [role] → model)
-		if strings.Contains(message, "🔧 [") && strings.Contains(message, "→") {
-			role, model := extractModelAssignment(message)
-			if role != "" && model != "" {
-				p.Send(ModelAssignedMsg{Role: role, Model: model})
-			}
-		}
-
-		// Detect leader/moderator/ui_creator phase starts
-		if strings.Contains(message, "Team Leader synthesizing") {
-			p.Send(AgentUpdateMsg{Role: "team_leader", Status: "working", Message: "Synthesizing round...", Speech: ""})
-		}
-		if strings.Contains(message, "Final Validation") {
-			p.Send(AgentUpdateMsg{Role: "moderator", Status: "working", Message: "Scoring ideas...", Speech: ""})
-		}
-		if strings.Contains(message, "Final Selection") {
-			p.Send(AgentUpdateMsg{Role: "team_leader", Status: "working", Message: "Selecting best idea...", Speech: ""})
-		}
-		if strings.Contains(message, "Creating Visual Idea Sheet") {
-			p.Send(AgentUpdateMsg{Role: "ui_creator", Status: "working", Message: "Painting the vision...", Speech: ""})
-		}
-		if strings.Contains(message, "Team Leader Kickoff") {
-			p.Send(AgentUpdateMsg{Role: "team_leader", Status: "working", Message: "Setting the direction...", Speech: ""})
-		}
-
-		// Update progress based on round detection
-		round := extractRound(message)
-		if round > 0 {
-			progress := float64(round) / float64(config.MaxRounds)
-			p.Send(ProgressMsg{
-				Phase:    extractPhase(message),
-				Round:    round,
-				Progress: progress,
-			})
-		}
 	}
 
-	// Run the discussion
-	err := orch.StartDiscussion(topic)
+	// Typed events drive the agent cards, progress bar and idea board
+	orch.Subscribe(func(ev orchestrator.Event) {
+		handleEvent(p, orch, config, ev)
+	})
 
-	if err != nil {
+	// Snapshots keep the idea count and failures current
+	orch.Watch(func(d *models.Discussion) {
+		p.Send(SnapshotMsg{Discussion: d})
+	})
+
+	// Run the discussion; completion is signalled by the Completed event
+	if err := orch.StartDiscussion(topic); err != nil {
 		p.Send(ErrorMsg{Err: err})
 		discussionResult.err = err
-		return
 	}
+}
 
-	// Get the discussion results
-	discussion := orch.GetDiscussion()
-	discussionResult.discussion = discussion
-
-	// Send ideas to TUI
-	if discussion != nil {
-		for _, idea := range discussion.Ideas {
-			ideaCopy := idea
-			p.Send(IdeaGeneratedMsg{Idea: &ideaCopy})
-		}
+// Phases in the order they run: the setup phases once, the round phases in
+// every round, then the closing phases once.
+var (
+	setupPhases = []orchestrator.Phase{
+		orchestrator.PhaseModelAssignment,
+		orchestrator.PhaseKickoff,
+	}
+	roundPhases = []orchestrator.Phase{
+		orchestrator.PhaseExploration,
+		orchestrator.PhaseSynthesis,
+		orchestrator.PhaseConvergence,
+	}
+	closingPhases = []orchestrator.Phase{
+		orchestrator.PhaseValidation,
+		orchestrator.PhaseDeepDive,
+		orchestrator.PhaseTournament,
+		orchestrator.PhaseSelection,
+		orchestrator.PhasePlanning,
+		orchestrator.PhaseSummary,
+		orchestrator.PhaseVisualization,
 	}
+)
 
-	// Mark completion
-	p.Send(CompleteMsg{Discussion: discussion})
-
-	// Mark all agents as complete
-	for _, role := range config.GetActiveAgentRoles() {
-		p.Send(AgentUpdateMsg{
-			Role:   string(role),
-			Status: "complete",
-		})
-	}
+// phaseProgress is how far through the whole discussion phase starts when it
+// runs in round of totalRounds. Every phase run is one step: a round phase is
+// step (round-1)·len(roundPhases) + its index after the setup phases, and the
+// closing phases follow the last round, so the bar only moves forward.
+func phaseProgress(phase orchestrator.Phase, round, totalRounds int) float64 {
+	totalRounds = max(totalRounds, 1)
+	round = min(max(round, 1), totalRounds)
+	roundSteps := totalRounds * len(roundPhases)
+	total := len(setupPhases) + roundSteps + len(closingPhases)
+
+	step := 0
+	if i := slices.Index(setupPhases, phase); i >= 0 {
+		step = i
+	} else if i := slices.Index(roundPhases, phase); i >= 0 {
+		step = len(setupPhases) + (round-1)*len(roundPhases) + i
+	} else if i := slices.Index(closingPhases, phase); i >= 0 {
+		step = len(setupPhases) + roundSteps + i
+	}
+	return float64(step) / float64(total)
 }
 
-// Helper functions to extract information from progress messages
-
-func extractPhase(message string) string {
-	if strings.Contains(message, "Kickoff") {
-		return "Team Leader Kickoff"
-	}
-	if strings.Contains(message, "Exploration") {
-		return "Exploration & Ideation"
-	}
-	if strings.Contains(message, "Validation") {
-		return "Validation & Scoring"
-	}
-	if strings.Contains(message, "Selection") {
-		return "Final Selection"
-	}
-	if strings.Contains(message, "Visualization") {
-		return "Creating Idea Sheet"
-	}
-	if strings.Contains(message, "synthesizing") {
-		return "Leader Synthesis"
-	}
-	return "Processing"
+// agentActivity is the status line shown on an agent card while it works.
+var agentActivity = map[orchestrator.Phase]string{
+	orchestrator.PhaseModelAssignment: "Assigning models...",
+	orchestrator.PhaseKickoff:         "Setting the direction...",
+	orchestrator.PhaseExploration:     "Contributing ideas...",
+	orchestrator.PhaseSynthesis:       "Synthesizing round...",
+	orchestrator.PhaseConvergence:     "Checking convergence...",
+	orchestrator.PhaseValidation:      "Scoring ideas...",
+	orchestrator.PhaseDeepDive:        "Deep diving...",
+	orchestrator.PhaseTournament:      "Judging head-to-head...",
+	orchestrator.PhaseSelection:       "Selecting best idea...",
+	orchestrator.PhasePlanning:        "Planning the build...",
+	orchestrator.PhaseSummary:         "Writing the summary...",
+	orchestrator.PhaseVisualization:   "Painting the vision...",
 }
 
-func extractRound(message string) int {
-	// Try to extract round number from messages like "Round 1 of 3"
-	if strings.Contains(message, "Round") {
-		parts := strings.Split(message, "Round")
-		if len(parts) > 1 {
-			// Try to parse the number
-			var round int
-			fmt.Sscanf(parts[1], "%d", &round)
-			if round > 0 {
-				return round
-			}
+// handleEvent translates an orchestrator event into TUI messages.
+func handleEvent(p *tea.Program, orch *orchestrator.ConfigurableOrchestrator, config *models.TeamConfig, ev orchestrator.Event) {
+	switch ev.Type {
+	case orchestrator.EventPhaseStarted:
+		round := ev.Round
+		if round < 1 {
+			round = 1
 		}
-	}
-	return 1
-}
+		_, totalRounds := config.RoundLimits()
+		p.Send(ProgressMsg{
+			Phase:    ev.Phase.Label(),
+			Round:    round,
+			Progress: phaseProgress(ev.Phase, round, totalRounds),
+		})
 
-func extractAgentRole(message string) string {
-	lowerMsg := strings.ToLower(message)
+	case orchestrator.EventAgentStarted:
+		message, ok := agentActivity[ev.Phase]
+		if !ok {
+			message = "Working..."
+		}
+		// Empty speech clears the bubble so streaming fills it fresh
+		p.Send(AgentUpdateMsg{Role: ev.Role, Status: "working", Message: message})
 
-	if strings.Contains(lowerMsg, "team leader") {
-		return "team_leader"
-	}
-	if strings.Contains(lowerMsg, "ideation") {
-		return "ideation"
-	}
-	if strings.Contains(lowerMsg, "moderator") {
-		return "moderator"
-	}
-	if strings.Contains(lowerMsg, "researcher") {
-		return "researcher"
-	}
-	if strings.Contains(lowerMsg, "critic") {
-		return "critic"
-	}
-	if strings.Contains(lowerMsg, "implementer") {
-		return "implementer"
-	}
-	if strings.Contains(lowerMsg, "ui creator") {
-		return "ui_creator"
-	}
+	case orchestrator.EventAgentChunk:
+		p.Send(AgentChunkMsg{Role: ev.Role, Chunk: ev.Text})
 
-	return ""
-}
+	case orchestrator.EventAgentFinished:
+		p.Send(AgentUpdateMsg{
+			Role:    ev.Role,
+			Status:  "working",
+			Message: "Just spoke",
+			Speech:  cleanSpeechContent(ev.Text),
+		})
 
-func extractAgentMessage(message string) string {
-	// Extract the meaningful part of the message
-	if strings.Contains(message, "contributing") {
-		return "Contributing ideas..."
-	}
-	if strings.Contains(message, "synthesizing") {
-		return "Synthesizing discussion..."
-	}
-	if strings.Contains(message, "evaluating") {
-		return "Evaluating ideas..."
-	}
-	return "Working..."
-}
+	case orchestrator.EventIdeaAdded:
+		p.Send(IdeaGeneratedMsg{Idea: ev.Idea})
 
-// extractSpeech parses "📣 [role] speech content" messages
-func extractSpeech(message string) (string, string) {
-	idx := strings.Index(message, "📣 [")
-	if idx < 0 {
-		return "", ""
-	}
-	rest := message[idx+len("📣 ["):]
-	endBracket := strings.Index(rest, "] ")
-	if endBracket < 0 {
-		return "", ""
+	case orchestrator.EventIdeaScored:
+		p.Send(IdeaScoredMsg{Idea: ev.Idea})
+
+	case orchestrator.EventModelAssigned:
+		p.Send(ModelAssignedMsg{Role: ev.Role, Model: ev.Model})
+
+	case orchestrator.EventRoundSummary:
+		if ev.Summary != nil {
+			p.Send(RoundSummaryMsg{Summary: ev.Summary})
+		}
+
+	case orchestrator.EventError:
+		if ev.Role == "" {
+			return // run-level failures arrive as ErrorMsg from StartDiscussion
+		}
+		p.Send(LogMsg(fmt.Sprintf("⚠️  [%s] %s: %s", ev.Role, ev.Cause, ev.Text)))
+		p.Send(AgentUpdateMsg{Role: ev.Role, Status: "failed", Message: "Hit a glitch", Speech: fmt.Sprintf("⚠️ %s: %s", ev.Cause, ev.Text)})
+
+	case orchestrator.EventCompleted:
+		discussion := orch.Snapshot()
+		discussionResult.discussion = discussion
+
+		// Mark all agents as complete, then the run
+		for _, role := range config.GetActiveAgentRoles() {
+			p.Send(AgentUpdateMsg{
+				Role:   string(role),
+				Status: "complete",
+			})
+		}
+		p.Send(CompleteMsg{Discussion: discussion})
 	}
-	role := rest[:endBracket]
-	speech := strings.TrimSpace(rest[endBracket+2:])
-	return role, cleanSpeechContent(speech)
 }
 
 // cleanSpeechContent distills raw LLM output into a short conversational soundbite.
@@ -277,6 +216,7 @@ func cleanSpeechContent(text string) string {
 	}
 
 	// JSON content (ideation agent mostly) — extract idea titles
+	text = strings.TrimSpace(strings.TrimPrefix(text, "```json"))
 	if strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[") {
 		var titles []string
 		for _, line := range strings.Split(text, "\n") {
@@ -377,24 +317,3 @@ func extractJSONValue(line string) string {
 	val = strings.Trim(val, `"`)
 	return val
 }
-
-// extractModelAssignment parses "🔧 [role] → model" messages.
-func extractModelAssignment(message string) (string, string) {
-	idx := strings.Index(message, "🔧 [")
-	if idx < 0 {
-		return "", ""
-	}
-	rest := message[idx+len("🔧 ["):]
-	endBracket := strings.Index(rest, "]")
-	if endBracket < 0 {
-		return "", ""
-	}
-	role := strings.TrimSpace(rest[:endBracket])
-	// Find "→" separator
-	arrowIdx := strings.Index(rest, "→")
-	if arrowIdx < 0 {
-		return "", ""
-	}
-	model := strings.TrimSpace(rest[arrowIdx+len("→"):])
-	return role, model
-}